@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//CommandOptionsWhereApplication used for the command options (flags)
+type CommandOptionsWhereApplication struct {
+	AppName string `json:"appName"`
+}
+
+//ApplicationLocation is the latest deployment of an application to an app server on an environment
+type ApplicationLocation struct {
+	Environment    string          `json:"environment"`
+	AppServerName  string          `json:"appServerName"`
+	Release        string          `json:"release"`
+	Version        string          `json:"version"`
+	State          DeploymentState `json:"state"`
+	DeploymentDate int64           `json:"deploymentDate"`
+}
+
+//ApplicationLocations is the matrix of the environments and app servers with the latest deployment of an application
+type ApplicationLocations struct {
+	ApplicationName string                `json:"applicationName"`
+	Environments    []string              `json:"environments"` //environments with a deployment in the order of the server
+	AppServers      []string              `json:"appServers"`   //app servers with a deployment sorted by name
+	Locations       []ApplicationLocation `json:"locations"`    //sorted by environment and app server
+}
+
+//Location returns the latest deployment of the application to the app server on the environment
+func (locations *ApplicationLocations) Location(environment string, appServer string) (*ApplicationLocation, bool) {
+	for i := range locations.Locations {
+		if locations.Locations[i].Environment == environment && locations.Locations[i].AppServerName == appServer {
+			return &locations.Locations[i], true
+		}
+	}
+	return nil, false
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsWhereApplication) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.AppName != "", "want appName")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//WhereApplication returns the version and state of the latest deployment of an application on each environment and app server
+func WhereApplication(cli *Cli, commandOptions *CommandOptionsWhereApplication) (*ApplicationLocations, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+
+	commandOptionsGet := CommandOptionsGetDeployment{}
+	commandOptionsGet.AppName = []string{commandOptions.AppName}
+	commandOptionsGet.TrackingID = -1
+	commandOptionsGet.OnlyLatest = true
+	deployments, err := GetDeployment(cli, &commandOptionsGet)
+	if err != nil {
+		return nil, err
+	}
+	environments, err := GetEnvironments(cli)
+	if err != nil {
+		return nil, err
+	}
+	return newApplicationLocations(commandOptions.AppName, deployments, environments), nil
+}
+
+//newApplicationLocations returns the matrix of the deployments of the application, the environments are ordered like the given environments
+func newApplicationLocations(appName string, deployments Deployments, environments Environments) *ApplicationLocations {
+	locations := &ApplicationLocations{ApplicationName: appName, Environments: []string{}, AppServers: []string{}, Locations: []ApplicationLocation{}}
+	for _, deployment := range deployments {
+		for _, app := range deployment.AppsWithVersion {
+			if app.ApplicationName != appName {
+				continue
+			}
+			locations.Locations = append(locations.Locations, ApplicationLocation{
+				Environment:    deployment.EnvironmentName,
+				AppServerName:  deployment.AppServerName,
+				Release:        deployment.ReleaseName,
+				Version:        app.Version,
+				State:          deployment.State,
+				DeploymentDate: deployment.DeploymentDate,
+			})
+			if !util.Contains(deployment.AppServerName, locations.AppServers) {
+				locations.AppServers = append(locations.AppServers, deployment.AppServerName)
+			}
+		}
+	}
+	sort.Strings(locations.AppServers)
+
+	//Environments unknown to the server (not expected) are appended sorted by name
+	order := map[string]int{}
+	for i, environment := range environments {
+		order[environment.Name] = i
+	}
+	sort.SliceStable(locations.Locations, func(i, j int) bool {
+		a, b := locations.Locations[i], locations.Locations[j]
+		if a.Environment != b.Environment {
+			orderA, knownA := order[a.Environment]
+			orderB, knownB := order[b.Environment]
+			if knownA != knownB {
+				return knownA
+			}
+			if knownA && orderA != orderB {
+				return orderA < orderB
+			}
+			return a.Environment < b.Environment
+		}
+		return a.AppServerName < b.AppServerName
+	})
+	for _, location := range locations.Locations {
+		if !util.Contains(location.Environment, locations.Environments) {
+			locations.Environments = append(locations.Environments, location.Environment)
+		}
+	}
+	return locations
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewApplicationLocationsOrdersByEnvironment(t *testing.T) {
+
+	// given
+	environments := Environments{{Name: "B"}, {Name: "Y"}, {Name: "P"}}
+	deployments := Deployments{
+		newTestDeployment(1, 0, DeploymentStateSuccess, "testapp", "1.1"),
+		newTestDeployment(2, 0, DeploymentStateFailed, "other", "2.0", "testapp", "1.2"),
+		newTestDeployment(3, 0, DeploymentStateSuccess, "testapp", "1.0"),
+		newTestDeployment(4, 0, DeploymentStateSuccess, "other", "2.0"),
+	}
+	deployments[0].EnvironmentName, deployments[0].AppServerName = "P", "Test"
+	deployments[1].EnvironmentName, deployments[1].AppServerName = "B", "Test"
+	deployments[2].EnvironmentName, deployments[2].AppServerName = "B", "Batch"
+	deployments[3].EnvironmentName, deployments[3].AppServerName = "Y", "Other"
+
+	// when
+	locations := newApplicationLocations("testapp", deployments, environments)
+
+	// then
+	assertString(t, "B,P", strings.Join(locations.Environments, ","), "environments")
+	assertString(t, "Batch,Test", strings.Join(locations.AppServers, ","), "app servers")
+	if len(locations.Locations) != 3 {
+		t.Fatalf("Expecting 3 locations, got %v", locations.Locations)
+	}
+	location, found := locations.Location("B", "Test")
+	if !found {
+		t.Fatalf("Expecting location of Test on B")
+	}
+	assertString(t, "1.2", location.Version, "version")
+	assertString(t, string(DeploymentStateFailed), string(location.State), "state")
+	if _, found := locations.Location("Y", "Test"); found {
+		t.Errorf("Expecting no location of Test on Y")
+	}
+}
+
+func TestWhereApplicationWantsAppName(t *testing.T) {
+
+	// given
+	cli := Cli{}
+
+	// when
+	_, err := WhereApplication(&cli, &CommandOptionsWhereApplication{})
+
+	// then
+	if err == nil || err.Error() != "want appName" {
+		t.Errorf("Expecting error want appName, got %v", err)
+	}
+}
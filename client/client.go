@@ -28,6 +28,18 @@ type Client struct {
 
 	// client used to send and receive http requests.
 	client *http.Client
+
+	// deployment policy, read from the policy file of the config on the first use
+	policy *Policy
+
+	// promotion config with the environments resolved to their names, on the first use
+	promotion *PromotionConfig
+
+	// releases sorted by installation date, read on the first use
+	releases Releases
+
+	// environments of the server, read on the first use
+	environments Environments
 }
 
 // NewClient creates a new liima client from the config
@@ -120,12 +132,12 @@ func newTLSClientConfig(config *Config) (*tls.Config, error) {
 //Method: http.MethodX
 //URL: Resturl
 //The bodyType will be marshaled to the rest body, depending the method
-//The result will be unmarshaled to the responseType
+//The result will be unmarshaled to the responseType, if not nil
 func (c *Client) DoRequest(method string, url string, bodyType interface{}, responseType interface{}) error {
 
-	//Setup body if MethodPost
+	//Setup body if MethodPost or MethodPut
 	bData := []byte{}
-	if method == http.MethodPost {
+	if method == http.MethodPost || method == http.MethodPut {
 		bDataloc, err := json.Marshal(bodyType)
 		if err != nil {
 			return fmt.Errorf("Couldn't marshal body %v, %v", bodyType, err)
@@ -187,7 +199,10 @@ func (c *Client) DoRequest(method string, url string, bodyType interface{}, resp
 		return fmt.Errorf(resp.Status + " : " + string(data))
 	}
 
-	//Unmarshal json respond to responseType
+	//Unmarshal json respond to responseType, no content if responseType is nil (for example http.StatusNoContent)
+	if responseType == nil || len(data) == 0 {
+		return nil
+	}
 	err = json.Unmarshal(data, responseType)
 	if err != nil {
 		return fmt.Errorf("Couldn't unmarshal response: %s\n %s", err, data)
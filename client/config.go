@@ -17,6 +17,15 @@ type Config struct {
 
 	// TLSClientConfig contains settings to enable transport layer security
 	TLSClientConfig
+
+	// Path to the deployment policy file (YAML) with windows, freezes and required reasons per environment
+	PolicyFile string
+
+	// Promotion contains the allowed promotions between environments
+	Promotion PromotionConfig
+
+	// Notification contains the notifications sent on deployment results
+	Notification NotificationConfig
 }
 
 // TLSClientConfig contains settings to enable transport layer security
@@ -44,6 +53,8 @@ func (config *Config) Validate() []error {
 	}
 
 	validationErrors = append(validationErrors, config.TLSClientConfig.Validate()...)
+	validationErrors = append(validationErrors, config.Promotion.Validate()...)
+	validationErrors = append(validationErrors, config.Notification.Validate()...)
 	return validationErrors
 }
 
@@ -12,9 +12,6 @@ import (
 	"github.com/liimaorg/liimactl/client/util"
 )
 
-//DateTimeFormat defines the actual date time format
-const DateTimeFormat = "2006-01-02 15:04MST"
-
 //LiimaDateTimeFormat defines the format for Liima UTC
 const LiimaDateTimeFormat = "2006-01-02T15:04:05-0700"
 
@@ -55,26 +52,46 @@ type CommandOptionsCreateDeployment struct {
 	Environment          string   `json:"environmentName"`
 	Release              string   `json:"releaseName"`
 	DeploymentDate       string   `json:"deploymentDate"`
+	TimeZone             string   //Timezone of the DeploymentDate, local timezone if empty
 	ExecuteShakedownTest bool     `json:"executeShakedownTest"`
 	Key                  []string `json:"key"`
 	Value                []string `json:"value"`
 	Wait                 bool     //Wait as long the WaitTime until the deployment success or failed
 	MaxWaitTime          int      //Max wait time [seconds] until the deployment success or failed
 	FromEnvironment      string   //Deploy last deployment from given environment
+	PolicyOptions
+	policyChecked bool //The policy is already checked, for example by PromoteDeployments
+}
+
+//CreateDeploymentError is the error on creating the deployment of an app server
+type CreateDeploymentError struct {
+	AppServerName string
+	Err           error
+}
+
+func (e *CreateDeploymentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CreateDeploymentError) Unwrap() error {
+	return e.Err
 }
 
 //Validate the given command options
-func (commandOption *CommandOptionsCreateDeployment) validate() error {
+func (commandOption *CommandOptionsCreateDeployment) validate(cli *Cli) error {
 
 	//Errorlist
 	var errorList []string
 	//Checks and add to errorList if an error
 	util.Check(&errorList, commandOption.AppServer != "", "want appServer")
 	util.Check(&errorList, len(commandOption.Key) == len(commandOption.Value), "want same count of key and value, got key %d != value %d", len(commandOption.Key), len(commandOption.Value))
-	util.Check(&errorList, util.ValidateSingleChar(commandOption.Environment), "want environment with one char, got %s", commandOption.Environment)
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	if _, err := deploymentTime(commandOption.DeploymentDate, commandOption.TimeZone, time.Now()); err != nil {
+		errorList = append(errorList, err.Error())
+	}
 	//Copy from environment, don't check AppName and AppVersion
 	if commandOption.FromEnvironment != "" {
-		util.Check(&errorList, util.ValidateSingleChar(commandOption.FromEnvironment), "want FromEnvironment with one char, got %s", commandOption.FromEnvironment)
+		checkEnvironment(cli, &errorList, &commandOption.FromEnvironment, "FromEnvironment")
 	} else {
 		util.Check(&errorList, len(commandOption.AppName) > 0, "want appName")
 		util.Check(&errorList, len(commandOption.AppVersion) > 0, "want appVersion")
@@ -87,10 +104,22 @@ func (commandOption *CommandOptionsCreateDeployment) validate() error {
 	return nil
 }
 
+//deploymentTime parses the deployment date in the given timezone, no date returns the zero time (deploy immediately)
+func deploymentTime(date string, timeZone string, now time.Time) (time.Time, error) {
+	loc, err := util.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if strings.TrimSpace(date) == "" {
+		return time.Time{}, nil
+	}
+	return util.ParseDateTime(date, now.In(loc))
+}
+
 //CreateDeployment create a deployment and returns the deploymentresponse from the client
 func CreateDeployment(cli *Cli, commandOptions *CommandOptionsCreateDeployment) (*DeploymentResponse, error) {
 
-	if err := commandOptions.validate(); err != nil {
+	if err := commandOptions.validate(cli); err != nil {
 		return nil, err
 	}
 
@@ -102,27 +131,32 @@ func CreateDeployment(cli *Cli, commandOptions *CommandOptionsCreateDeployment)
 	deploymentRequest.AppServerName = commandOptions.AppServer
 	deploymentRequest.EnvironmentName = commandOptions.Environment
 	deploymentRequest.ExecuteShakedownTest = commandOptions.ExecuteShakedownTest
-	deploymentRequest.ReleaseName = &commandOptions.Release
-	if commandOptions.Release == "" {
-		deploymentRequest.ReleaseName = nil
+	deploymentRequest.ReleaseName = nil
+	if commandOptions.Release != "" {
+		//Resolve the release and aliases ("latest", "next") before sending the deployment request
+		release, err := ResolveRelease(cli, commandOptions.Release)
+		if err != nil {
+			return nil, err
+		}
+		deploymentRequest.ReleaseName = &release
 	}
 	//Set deploymentdate
-	actTimeZone, _ := time.Now().In(time.Local).Zone() //Load act timezone
-	//Parse time in actual timezone
-	t, _ := time.Parse(DateTimeFormat, commandOptions.DeploymentDate+actTimeZone)
+	t, err := deploymentTime(commandOptions.DeploymentDate, commandOptions.TimeZone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !commandOptions.policyChecked {
+		if t, err = checkPolicy(cli, "create "+commandOptions.AppServer, commandOptions.Environment, t, &commandOptions.PolicyOptions); err != nil {
+			return nil, err
+		}
+	}
 	//Format to liima UTC format
 	deploymentRequest.DeploymentDate = t.Format(LiimaDateTimeFormat)
 
 	//Get application and version from last deployment of given "from environment"
 	if commandOptions.FromEnvironment != "" {
-		commandOptionsGet := CommandOptionsGetDeployment{}
-		commandOptionsGet.Environment = []string{commandOptions.FromEnvironment}
-		commandOptionsGet.AppServer = []string{commandOptions.AppServer}
-		commandOptionsGet.TrackingID = -1
-		commandOptionsGet.OnlyLatest = true
-		commandOptionsGet.DeploymentState = []DeploymentState{DeploymentStateSuccess}
 		//Get last deployment
-		deployments, err := GetDeployment(cli, &commandOptionsGet)
+		deployments, err := getLatestSuccessfulDeployments(cli, commandOptions.FromEnvironment, []string{commandOptions.AppServer})
 		if err != nil {
 			return nil, err
 		}
@@ -130,6 +164,13 @@ func CreateDeployment(cli *Cli, commandOptions *CommandOptionsCreateDeployment)
 			return nil, fmt.Errorf("There was an error on creating the deployment, no deployment found from environment: %s", commandOptions.FromEnvironment)
 		}
 		lastDeployment := deployments[0]
+		violations, err := checkPromotionPath(cli, commandOptions.FromEnvironment, commandOptions.Environment, deployments[:1])
+		if err != nil {
+			return nil, err
+		}
+		if violation, found := violations[lastDeployment.AppServerName]; found {
+			return nil, fmt.Errorf("Soak time of the promotion path not reached for app server %s: %s", lastDeployment.AppServerName, violation)
+		}
 		//Set app and version
 		for i := 0; i < len(lastDeployment.AppsWithVersion); i++ {
 			appVersion := appsWithVersion{
@@ -163,6 +204,7 @@ func CreateDeployment(cli *Cli, commandOptions *CommandOptionsCreateDeployment)
 
 	//Call rest client
 	deploymentResponse := &DeploymentResponse{}
+	created := time.Now()
 	if err := cli.Client.DoRequest(http.MethodPost, url, &deploymentRequest, &deploymentResponse); err != nil {
 
 		//Error response "Failed dependency" -> example: node active=false in liima appserver configuration
@@ -176,39 +218,59 @@ func CreateDeployment(cli *Cli, commandOptions *CommandOptionsCreateDeployment)
 
 		return deploymentResponse, err
 	}
+	deploymentResponse.created = created
 
 	//Wait on deployment success or failed
 	if commandOptions.Wait && commandOptions.MaxWaitTime > 5 {
-		commandOptionsGet := CommandOptionsGetDeployment{
-			TrackingID: deploymentResponse.TrackingID,
+		finished, err := waitForDeployment(cli, deploymentResponse, commandOptions.MaxWaitTime)
+
+		//Notify also on errors and timeouts, with the last known state of the deployment
+		notifyFinished(cli, "create", commandOptions.Environment, Deployments{*finished}, err)
+		if err != nil {
+			return nil, err
 		}
+		deploymentResponse = finished
+	}
 
-		//Timeout 10min = 600sec / 5sec = 120 counts
-		maxCounts := commandOptions.MaxWaitTime / 5
-		for i := 0; i < maxCounts; i++ {
-			deployments, err := GetDeployment(cli, &commandOptionsGet)
-			if err != nil {
-				return nil, err
-			}
+	//Return response
+	return deploymentResponse, nil
+}
 
-			if len(deployments) != 1 {
-				return nil, fmt.Errorf("There was an error on creating the deployment, no deployment get")
-			}
+//waitForDeployment waits until the deployment succeeded or failed or maxWaitTime (seconds) is reached
+//The deployment with its last known state is also returned on an error
+func waitForDeployment(cli *Cli, deploymentResponse *DeploymentResponse, maxWaitTime int) (*DeploymentResponse, error) {
+	commandOptionsGet := CommandOptionsGetDeployment{
+		TrackingID: deploymentResponse.TrackingID,
+	}
 
-			log.Printf("AppServer: %-30s State: %-20s\n", deployments[0].AppServerName, deployments[0].State)
+	//Timeout 10min = 600sec / 5sec = 120 counts
+	maxCounts := maxWaitTime / 5
+	for i := 0; i < maxCounts; i++ {
+		deployments, err := GetDeployment(cli, &commandOptionsGet)
+		if err != nil {
+			return deploymentResponse, err
+		}
 
-			deploymentResponse = &deployments[0]
-			if deployments[0].State == DeploymentStateFailed || deployments[0].State == DeploymentStateSuccess {
-				break
-			}
-			if i < maxCounts-1 {
-				time.Sleep(time.Second * 5)
-			} else {
-				return nil, fmt.Errorf("Timeout on deployment")
-			}
+		if len(deployments) != 1 {
+			return deploymentResponse, fmt.Errorf("There was an error on creating the deployment, no deployment get")
 		}
-	}
 
-	//Return response
+		log.Printf("AppServer: %-30s State: %-20s\n", deployments[0].AppServerName, deployments[0].State)
+
+		if deployments[0].State != deploymentResponse.State {
+			notifyStateChange(cli, &deployments[0])
+		}
+		deployments[0].created = deploymentResponse.created
+		deploymentResponse = &deployments[0]
+		if deployments[0].State == DeploymentStateFailed || deployments[0].State == DeploymentStateSuccess {
+			deploymentResponse.Duration = time.Since(deploymentResponse.created)
+			break
+		}
+		if i < maxCounts-1 {
+			time.Sleep(time.Second * 5)
+		} else {
+			return deploymentResponse, fmt.Errorf("Timeout on deployment")
+		}
+	}
 	return deploymentResponse, nil
 }
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateDeploymentWithInvalidDate(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	commandOptions := CommandOptionsCreateDeployment{
+		AppServer:      "testApp",
+		AppName:        []string{"test1"},
+		AppVersion:     []string{"1.1.1"},
+		Environment:    "T",
+		DeploymentDate: "2018-02-31 17:00",
+	}
+
+	// when
+	_, err := CreateDeployment(&cli, &commandOptions)
+
+	// then
+	if err == nil {
+		t.Errorf("Expecting an error on an invalid deployment date")
+	}
+}
+
+func TestDeploymentTimeInTimeZone(t *testing.T) {
+
+	// given
+	now := time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)
+
+	// when
+	got, err := deploymentTime("tomorrow 06:00", "Europe/Zurich", now)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	//Day of the switch to winter time, UTC+1
+	assertString(t, "2026-10-25T06:00:00+0100", got.Format(LiimaDateTimeFormat), "deploymentDate")
+
+	if got, _ := deploymentTime("", "Europe/Zurich", now); !got.IsZero() {
+		t.Errorf("Expecting zero time without date, got %v", got)
+	}
+	if _, err := deploymentTime("2026-10-25 06:00", "Europe/Nowhere", now); err == nil {
+		t.Errorf("Expecting an error on an invalid timezone")
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//historyPageSize is the number of deployments fetched per request
+const historyPageSize = 100
+
+//historyMaxPages limits the requests of a history, a server ignoring the paging would return the same page forever
+const historyMaxPages = 1000
+
+//CommandOptionsDeploymentHistory used for the command options (flags)
+type CommandOptionsDeploymentHistory struct {
+	AppServer          string `json:"appServerName"`
+	Environment        string `json:"environmentName"`
+	OnlyVersionChanges bool   //Only deployments which change the versions of the last successful deployment
+}
+
+//VersionChange is the change of an application version between two deployments
+type VersionChange struct {
+	ApplicationName string `json:"applicationName"`
+	OldVersion      string `json:"oldVersion"` //empty if the application is new
+	NewVersion      string `json:"newVersion"` //empty if the application was removed
+}
+
+//DeploymentHistoryEntry is a deployment with the version changes to the last successful deployment before
+type DeploymentHistoryEntry struct {
+	Deployment DeploymentResponse `json:"deployment"`
+	Changes    []VersionChange    `json:"changes"`
+}
+
+//DeploymentHistory is the chronological list of the deployments of an app server on an environment
+type DeploymentHistory []DeploymentHistoryEntry
+
+//Validate the given command options
+func (commandOption *CommandOptionsDeploymentHistory) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.AppServer != "", "want appServer")
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetDeploymentHistory returns all deployments of an app server on an environment sorted by the deployment date
+func GetDeploymentHistory(cli *Cli, commandOptions *CommandOptionsDeploymentHistory) (DeploymentHistory, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	deployments, err := getAllDeployments(cli, &CommandOptionsGetDeployment{
+		AppServer:   []string{commandOptions.AppServer},
+		Environment: []string{commandOptions.Environment},
+		TrackingID:  -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortByDeploymentDate(deployments)
+
+	return buildDeploymentHistory(deployments, commandOptions.OnlyVersionChanges), nil
+}
+
+//getAllDeployments pages through all deployments matching the command options
+//Deployments returned twice (new deployments shift the pages) are skipped. The paging stops at a page with only known deployments
+//(the server ignores the paging) or after historyMaxPages, the deployments until then are returned
+func getAllDeployments(cli *Cli, commandOptions *CommandOptionsGetDeployment) (Deployments, error) {
+	allDeployments := Deployments{}
+	seen := map[int]bool{}
+	commandOptions.MaxResults = historyPageSize
+	commandOptions.Offset = 0
+	for page := 0; page < historyMaxPages; page++ {
+		deployments, err := GetDeployment(cli, commandOptions)
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, deployment := range deployments {
+			if !seen[deployment.ID] {
+				seen[deployment.ID] = true
+				allDeployments = append(allDeployments, deployment)
+				added++
+			}
+		}
+		if len(deployments) < historyPageSize {
+			return allDeployments, nil
+		}
+		if added == 0 {
+			log.Printf("Paging of deployments stopped: page at offset %d has only deployments of the previous pages", commandOptions.Offset)
+			return allDeployments, nil
+		}
+		commandOptions.Offset += historyPageSize
+	}
+	log.Printf("Paging of deployments stopped after %d deployments", historyMaxPages*historyPageSize)
+	return allDeployments, nil
+}
+
+//sortByDeploymentDate sorts the deployments chronological, deployments with the same date by id
+func sortByDeploymentDate(deployments Deployments) {
+	sort.SliceStable(deployments, func(i, j int) bool {
+		if deployments[i].DeploymentDate != deployments[j].DeploymentDate {
+			return deployments[i].DeploymentDate < deployments[j].DeploymentDate
+		}
+		return deployments[i].ID < deployments[j].ID
+	})
+}
+
+//buildDeploymentHistory compares each deployment with the last successful deployment before
+func buildDeploymentHistory(deployments Deployments, onlyVersionChanges bool) DeploymentHistory {
+	history := DeploymentHistory{}
+	installed := map[string]string{}
+	for _, deployment := range deployments {
+		versions := deployment.versions()
+		changes := compareVersions(installed, versions)
+		if deployment.State == DeploymentStateSuccess {
+			installed = versions
+		}
+		if onlyVersionChanges && len(changes) == 0 {
+			continue
+		}
+		history = append(history, DeploymentHistoryEntry{Deployment: deployment, Changes: changes})
+	}
+	return history
+}
+
+//versions returns the application versions of a deployment by application name
+func (deployment *DeploymentResponse) versions() map[string]string {
+	versions := map[string]string{}
+	for _, app := range deployment.AppsWithVersion {
+		versions[app.ApplicationName] = app.Version
+	}
+	return versions
+}
+
+//compareVersions returns the changes between two application versions maps sorted by application name
+func compareVersions(oldVersions map[string]string, newVersions map[string]string) []VersionChange {
+	changes := []VersionChange{}
+	for app, newVersion := range newVersions {
+		if oldVersion := oldVersions[app]; oldVersion != newVersion {
+			changes = append(changes, VersionChange{ApplicationName: app, OldVersion: oldVersion, NewVersion: newVersion})
+		}
+	}
+	for app, oldVersion := range oldVersions {
+		if _, ok := newVersions[app]; !ok {
+			changes = append(changes, VersionChange{ApplicationName: app, OldVersion: oldVersion})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ApplicationName < changes[j].ApplicationName
+	})
+	return changes
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Bplotka/go-httpt"
+	"github.com/Bplotka/go-httpt/rt"
+)
+
+func TestBuildDeploymentHistory(t *testing.T) {
+
+	// given
+	deployments := Deployments{
+		newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0", "app2", "2.0"),
+		newTestDeployment(2, 2000, DeploymentStateSuccess, "app1", "1.0", "app2", "2.0"),
+		newTestDeployment(3, 3000, DeploymentStateFailed, "app1", "1.1", "app2", "2.0"),
+		newTestDeployment(4, 4000, DeploymentStateSuccess, "app1", "1.1"),
+	}
+
+	// when
+	history := buildDeploymentHistory(deployments, false)
+	onlyChanges := buildDeploymentHistory(deployments, true)
+
+	// then
+	if len(history) != 4 {
+		t.Fatalf("Expecting 4 history entries, got %d", len(history))
+	}
+	if len(history[0].Changes) != 2 || len(history[1].Changes) != 0 {
+		t.Errorf("Expecting 2 new applications and a redeploy, got %v and %v", history[0].Changes, history[1].Changes)
+	}
+	//The failed deployment didn't change the installed versions
+	if len(history[3].Changes) != 2 {
+		t.Fatalf("Expecting 2 changes to the last successful deployment, got %v", history[3].Changes)
+	}
+	assertString(t, "1.0", history[3].Changes[0].OldVersion, "oldVersion")
+	assertString(t, "1.1", history[3].Changes[0].NewVersion, "newVersion")
+	assertString(t, "app2", history[3].Changes[1].ApplicationName, "applicationName")
+	assertString(t, "", history[3].Changes[1].NewVersion, "newVersion")
+
+	if len(onlyChanges) != 3 {
+		t.Errorf("Expecting 3 history entries without the redeploy, got %d", len(onlyChanges))
+	}
+}
+
+func TestGetDeploymentHistory(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	history, err := GetDeploymentHistory(&cli, &CommandOptionsDeploymentHistory{AppServer: "Test", Environment: "T"})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("Expecting 1 history entry, got %d", len(history))
+	}
+	assertString(t, "Test", history[0].Deployment.AppServerName, "appServerName")
+
+	if _, err := GetDeploymentHistory(&cli, &CommandOptionsDeploymentHistory{Environment: "T"}); err == nil {
+		t.Errorf("Expecting an error without appServer")
+	}
+}
+
+//newTestDeployment creates a deployment with the given application name and version pairs
+func newTestDeployment(id int, date int64, state DeploymentState, appsAndVersions ...string) DeploymentResponse {
+	deployment := DeploymentResponse{ID: id, DeploymentDate: date, State: state, AppServerName: "Test"}
+	for i := 0; i+1 < len(appsAndVersions); i += 2 {
+		deployment.AppsWithVersion = append(deployment.AppsWithVersion, struct {
+			ApplicationName string `json:"applicationName"`
+			Version         string `json:"version"`
+		}{appsAndVersions[i], appsAndVersions[i+1]})
+	}
+	return deployment
+}
+
+func TestGetAllDeploymentsStopsIfPagingIsIgnored(t *testing.T) {
+
+	// given
+	page := Deployments{}
+	for i := 0; i < historyPageSize; i++ {
+		page = append(page, newTestDeployment(i, int64(i), DeploymentStateSuccess, "testapp", "1.0"))
+	}
+	body, _ := json.Marshal(page)
+	s := httpt.NewServer(t)
+	for i := 0; i < 2; i++ {
+		s.On(httpt.GET, "resources/deployments/filter").Push(rt.JSONResponseFunc(http.StatusOK, body))
+	}
+	cli := Cli{}
+	cli.Client = NewMockClientWithCustomHttpClient(s.HTTPClient())
+
+	// when
+	deployments, err := getAllDeployments(&cli, &CommandOptionsGetDeployment{TrackingID: -1})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != historyPageSize {
+		t.Errorf("Expecting the %d deployments of the first page, got %d", historyPageSize, len(deployments))
+	}
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//CommandOptionsDiffEnvironments used for the command options (flags)
+type CommandOptionsDiffEnvironments struct {
+	FromEnvironment string   `json:"fromEnvironment"` //Environment with the versions to promote
+	ToEnvironment   string   `json:"toEnvironment"`   //Environment to compare with
+	AppServer       []string `json:"appServerName"`   //Only the given app servers, all if empty
+}
+
+// DiffState is the difference of an app server or application between two environments
+type DiffState string
+
+//Enumeration of diff states, seen as changes of a promotion from the FromEnvironment to the ToEnvironment
+const (
+	DiffStateAdded      DiffState = "added"      //only on the FromEnvironment
+	DiffStateRemoved    DiffState = "removed"    //only on the ToEnvironment
+	DiffStateUpgraded   DiffState = "upgraded"   //newer version on the FromEnvironment
+	DiffStateDowngraded DiffState = "downgraded" //older version on the FromEnvironment
+	DiffStateChanged    DiffState = "changed"    //same versions, but different release
+	DiffStateUnchanged  DiffState = "unchanged"
+)
+
+//ApplicationDiff is the version difference of an application
+type ApplicationDiff struct {
+	ApplicationName string    `json:"applicationName"`
+	FromVersion     string    `json:"fromVersion"`
+	ToVersion       string    `json:"toVersion"`
+	State           DiffState `json:"state"`
+}
+
+//AppServerDiff is the difference of the latest successful deployments of an app server
+type AppServerDiff struct {
+	AppServerName string            `json:"appServerName"`
+	FromRelease   string            `json:"fromRelease"`
+	ToRelease     string            `json:"toRelease"`
+	State         DiffState         `json:"state"`
+	Applications  []ApplicationDiff `json:"applications"`
+}
+
+//EnvironmentDiff is the difference of all app servers between two environments
+type EnvironmentDiff struct {
+	FromEnvironment string          `json:"fromEnvironment"`
+	ToEnvironment   string          `json:"toEnvironment"`
+	AppServers      []AppServerDiff `json:"appServers"`
+}
+
+//HasDifferences returns true if at least one app server differs
+func (diff *EnvironmentDiff) HasDifferences() bool {
+	for _, appServer := range diff.AppServers {
+		if appServer.State != DiffStateUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsDiffEnvironments) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	checkEnvironment(cli, &errorList, &commandOption.FromEnvironment, "FromEnvironment")
+	checkEnvironment(cli, &errorList, &commandOption.ToEnvironment, "ToEnvironment")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//DiffEnvironments compares the latest successful deployments of each app server on two environments
+func DiffEnvironments(cli *Cli, commandOptions *CommandOptionsDiffEnvironments) (*EnvironmentDiff, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	fromDeployments, err := getLatestSuccessfulDeployments(cli, commandOptions.FromEnvironment, commandOptions.AppServer)
+	if err != nil {
+		return nil, err
+	}
+	toDeployments, err := getLatestSuccessfulDeployments(cli, commandOptions.ToEnvironment, commandOptions.AppServer)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffDeployments(fromDeployments, toDeployments)
+	diff.FromEnvironment = commandOptions.FromEnvironment
+	diff.ToEnvironment = commandOptions.ToEnvironment
+	return diff, nil
+}
+
+//diffDeployments compares the deployments by app server name
+func diffDeployments(fromDeployments Deployments, toDeployments Deployments) *EnvironmentDiff {
+	diff := &EnvironmentDiff{AppServers: []AppServerDiff{}}
+
+	from := map[string]DeploymentResponse{}
+	to := map[string]DeploymentResponse{}
+	names := []string{}
+	for _, deployment := range fromDeployments {
+		from[deployment.AppServerName] = deployment
+		names = append(names, deployment.AppServerName)
+	}
+	for _, deployment := range toDeployments {
+		to[deployment.AppServerName] = deployment
+		if _, ok := from[deployment.AppServerName]; !ok {
+			names = append(names, deployment.AppServerName)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fromDeployment, onFrom := from[name]
+		toDeployment, onTo := to[name]
+		appServerDiff := AppServerDiff{
+			AppServerName: name,
+			FromRelease:   fromDeployment.ReleaseName,
+			ToRelease:     toDeployment.ReleaseName,
+			Applications:  diffApplications(fromDeployment.versions(), toDeployment.versions()),
+		}
+		switch {
+		case !onTo:
+			appServerDiff.State = DiffStateAdded
+		case !onFrom:
+			appServerDiff.State = DiffStateRemoved
+		default:
+			appServerDiff.State = summarizeDiffStates(appServerDiff.Applications)
+			if appServerDiff.State == DiffStateUnchanged && appServerDiff.FromRelease != appServerDiff.ToRelease {
+				appServerDiff.State = DiffStateChanged
+			}
+		}
+		diff.AppServers = append(diff.AppServers, appServerDiff)
+	}
+	return diff
+}
+
+//diffApplications compares the application versions sorted by application name
+func diffApplications(fromVersions map[string]string, toVersions map[string]string) []ApplicationDiff {
+	applications := []ApplicationDiff{}
+	for app, fromVersion := range fromVersions {
+		applications = append(applications, ApplicationDiff{ApplicationName: app, FromVersion: fromVersion, ToVersion: toVersions[app]})
+	}
+	for app, toVersion := range toVersions {
+		if _, ok := fromVersions[app]; !ok {
+			applications = append(applications, ApplicationDiff{ApplicationName: app, ToVersion: toVersion})
+		}
+	}
+	for i := range applications {
+		applications[i].State = diffVersions(applications[i].FromVersion, applications[i].ToVersion)
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].ApplicationName < applications[j].ApplicationName
+	})
+	return applications
+}
+
+//diffVersions returns the diff state of a version on the from and to environment, empty if not deployed
+func diffVersions(fromVersion string, toVersion string) DiffState {
+	switch {
+	case toVersion == "":
+		return DiffStateAdded
+	case fromVersion == "":
+		return DiffStateRemoved
+	}
+	switch util.CompareVersions(fromVersion, toVersion) {
+	case 1:
+		return DiffStateUpgraded
+	case -1:
+		return DiffStateDowngraded
+	}
+	if fromVersion != toVersion {
+		return DiffStateChanged
+	}
+	return DiffStateUnchanged
+}
+
+//summarizeDiffStates returns the state of all applications: unchanged, the common state or changed
+func summarizeDiffStates(applications []ApplicationDiff) DiffState {
+	state := DiffStateUnchanged
+	for _, application := range applications {
+		switch {
+		case application.State == DiffStateUnchanged:
+		case state == DiffStateUnchanged:
+			state = application.State
+		case state != application.State:
+			return DiffStateChanged
+		}
+	}
+	return state
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestDiffDeployments(t *testing.T) {
+
+	// given
+	from := Deployments{
+		newTestDeployment(1, 0, DeploymentStateSuccess, "app1", "1.1", "app2", "2.0"),
+		newTestDeployment(2, 0, DeploymentStateSuccess, "app3", "1.0"),
+		newTestDeployment(3, 0, DeploymentStateSuccess, "app4", "1.0"),
+	}
+	from[1].AppServerName = "onlyFrom"
+	from[2].AppServerName = "same"
+	to := Deployments{
+		newTestDeployment(4, 0, DeploymentStateSuccess, "app1", "1.0", "app2", "2.0"),
+		newTestDeployment(5, 0, DeploymentStateSuccess, "app5", "1.0"),
+		newTestDeployment(6, 0, DeploymentStateSuccess, "app4", "1.0"),
+	}
+	to[1].AppServerName = "onlyTo"
+	to[2].AppServerName = "same"
+
+	// when
+	diff := diffDeployments(from, to)
+
+	// then
+	if len(diff.AppServers) != 4 {
+		t.Fatalf("Expecting 4 app servers, got %v", diff.AppServers)
+	}
+	assertString(t, "Test", diff.AppServers[0].AppServerName, "appServerName")
+	assertString(t, string(DiffStateUpgraded), string(diff.AppServers[0].State), "state")
+	assertString(t, string(DiffStateUpgraded), string(diff.AppServers[0].Applications[0].State), "state app1")
+	assertString(t, string(DiffStateUnchanged), string(diff.AppServers[0].Applications[1].State), "state app2")
+	assertString(t, string(DiffStateAdded), string(diff.AppServers[1].State), "state onlyFrom")
+	assertString(t, string(DiffStateRemoved), string(diff.AppServers[2].State), "state onlyTo")
+	assertString(t, string(DiffStateUnchanged), string(diff.AppServers[3].State), "state same")
+	if !diff.HasDifferences() {
+		t.Errorf("Expecting differences")
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		from string    //Argument
+		to   string    //Argument
+		want DiffState //Wanted testresult
+	}{
+		{"1.0", "", DiffStateAdded},
+		{"", "1.0", DiffStateRemoved},
+		{"1.1", "1.0", DiffStateUpgraded},
+		{"1.0", "1.1", DiffStateDowngraded},
+		{"1.0", "1.0", DiffStateUnchanged},
+		{"1.0.1", "1.0.1-SNAPSHOT", DiffStateUpgraded},
+		{"1_0", "1.0", DiffStateChanged},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		if got := diffVersions(tt.from, tt.to); got != tt.want {
+			t.Errorf("diffVersions(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//CommandOptionsDriftReport used for the command options (flags)
+type CommandOptionsDriftReport struct {
+	Environment        string   `json:"environmentName"`
+	AgainstEnvironment string   `json:"againstEnvironment"` //Expect the versions of the latest successful deployments of this environment
+	AppServer          []string `json:"appServerName"`      //Only the given app servers, all if empty
+}
+
+// DriftFinding is a finding of the drift report
+type DriftFinding string
+
+//Enumeration of drift findings
+const (
+	DriftFindingLatestFailed             DriftFinding = "latestFailed"             //the latest deployment failed
+	DriftFindingUnsuccessfulAfterSuccess DriftFinding = "unsuccessfulAfterSuccess" //a deployment after the latest successful deployment is not successful
+	DriftFindingVersionsDiffer           DriftFinding = "versionsDiffer"           //the versions differ from the expected versions
+)
+
+//AppServerDriftReport is the drift report of an app server
+type AppServerDriftReport struct {
+	AppServerName string              `json:"appServerName"`
+	Successful    *DeploymentResponse `json:"successful"` //latest successful deployment, nil if never successful
+	Latest        *DeploymentResponse `json:"latest"`     //latest deployment in any state
+	Findings      []DriftFinding      `json:"findings"`
+	Changes       []VersionChange     `json:"changes"` //changes from the deployed to the expected versions
+}
+
+//DriftReport is the drift report of all app servers of an environment
+type DriftReport struct {
+	Environment string                 `json:"environment"`
+	Expected    string                 `json:"expected"` //description of the expected versions
+	AppServers  []AppServerDriftReport `json:"appServers"`
+}
+
+//HasFindings returns true if at least one app server has findings
+func (report *DriftReport) HasFindings() bool {
+	for _, appServer := range report.AppServers {
+		if len(appServer.Findings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsDriftReport) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	if commandOption.AgainstEnvironment != "" {
+		checkEnvironment(cli, &errorList, &commandOption.AgainstEnvironment, "AgainstEnvironment")
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//CreateDriftReport reports app servers whose latest deployment failed, which have a newer non-successful deployment than the last success
+//or whose versions differ from the expected versions. Expected are the versions of the AgainstEnvironment, of the given expected snapshot or nothing if both are empty
+func CreateDriftReport(cli *Cli, commandOptions *CommandOptionsDriftReport, expected *Snapshot) (*DriftReport, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{Environment: commandOptions.Environment, AppServers: []AppServerDriftReport{}}
+	if commandOptions.AgainstEnvironment != "" {
+		var err error
+		expected, err = CreateSnapshot(cli, &CommandOptionsSnapshot{Environment: commandOptions.AgainstEnvironment, AppServer: commandOptions.AppServer})
+		if err != nil {
+			return nil, err
+		}
+		report.Expected = "environment " + commandOptions.AgainstEnvironment
+	} else if expected != nil {
+		report.Expected = "expected versions"
+	}
+
+	successful, err := getLatestSuccessfulDeployments(cli, commandOptions.Environment, commandOptions.AppServer)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := GetDeployment(cli, &CommandOptionsGetDeployment{
+		AppServer:   commandOptions.AppServer,
+		Environment: []string{commandOptions.Environment},
+		OnlyLatest:  true,
+		TrackingID:  -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//All app servers deployed or expected
+	expectedAppServers := map[string]*SnapshotAppServer{}
+	names := map[string]bool{}
+	if expected != nil {
+		for i := range expected.AppServers {
+			expectedAppServers[expected.AppServers[i].Name] = &expected.AppServers[i]
+			names[expected.AppServers[i].Name] = true
+		}
+	}
+	for _, deployment := range latest {
+		names[deployment.AppServerName] = true
+	}
+	sortedNames := []string{}
+	for name := range names {
+		if len(commandOptions.AppServer) == 0 || util.Contains(name, commandOptions.AppServer) {
+			sortedNames = append(sortedNames, name)
+		}
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		report.AppServers = append(report.AppServers, checkDrift(name, findDeployment(successful, name), findDeployment(latest, name), expectedAppServers[name], expected != nil))
+	}
+	return report, nil
+}
+
+//checkDrift returns the findings of an app server, the expected app server is nil if not expected
+//The versions are only compared for expected app servers, an expectation may list only some app servers
+func checkDrift(name string, successful *DeploymentResponse, latest *DeploymentResponse, expected *SnapshotAppServer, compareVersionsWithExpected bool) AppServerDriftReport {
+	appServer := AppServerDriftReport{AppServerName: name, Successful: successful, Latest: latest, Findings: []DriftFinding{}, Changes: []VersionChange{}}
+
+	if latest != nil && latest.State == DeploymentStateFailed {
+		appServer.Findings = append(appServer.Findings, DriftFindingLatestFailed)
+	} else if latest != nil && latest.State != DeploymentStateSuccess && successful != nil && latest.ID != successful.ID {
+		appServer.Findings = append(appServer.Findings, DriftFindingUnsuccessfulAfterSuccess)
+	}
+
+	if compareVersionsWithExpected && expected != nil {
+		deployedVersions := map[string]string{}
+		if successful != nil {
+			deployedVersions = successful.versions()
+		}
+		expectedVersions := map[string]string{}
+		for _, app := range expected.Applications {
+			expectedVersions[app.Name] = app.Version
+		}
+		appServer.Changes = compareVersions(deployedVersions, expectedVersions)
+		if len(appServer.Changes) > 0 {
+			appServer.Findings = append(appServer.Findings, DriftFindingVersionsDiffer)
+		}
+	}
+	return appServer
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestCheckDrift(t *testing.T) {
+
+	// given
+	successful := newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0")
+	failed := newTestDeployment(2, 2000, DeploymentStateFailed, "app1", "1.1")
+	canceled := newTestDeployment(3, 3000, DeploymentStateCanceled, "app1", "1.1")
+	expected := SnapshotAppServer{Name: "Test", Applications: []SnapshotApplication{{"app1", "1.1"}}}
+
+	//Tests
+	tests := []struct {
+		name       string              //Name of the test
+		successful *DeploymentResponse //Argument
+		latest     *DeploymentResponse //Argument
+		expected   *SnapshotAppServer  //Argument
+		compare    bool                //Argument
+		want       []DriftFinding      //Wanted testresult
+	}{
+		{"Ok", &successful, &successful, nil, false, []DriftFinding{}},
+		{"Failed", &successful, &failed, nil, false, []DriftFinding{DriftFindingLatestFailed}},
+		{"Canceled", &successful, &canceled, nil, false, []DriftFinding{DriftFindingUnsuccessfulAfterSuccess}},
+		{"VersionsDiffer", &successful, &successful, &expected, true, []DriftFinding{DriftFindingVersionsDiffer}},
+		{"NotExpected", &successful, &successful, nil, true, []DriftFinding{}},
+		{"ExpectedNotDeployed", nil, nil, &expected, true, []DriftFinding{DriftFindingVersionsDiffer}},
+		{"FailedAndVersionsDiffer", &successful, &failed, &expected, true, []DriftFinding{DriftFindingLatestFailed, DriftFindingVersionsDiffer}},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkDrift("Test", tt.successful, tt.latest, tt.expected, tt.compare)
+			if len(got.Findings) != len(tt.want) {
+				t.Fatalf("checkDrift() = %v, want %v", got.Findings, tt.want)
+			}
+			for i := range tt.want {
+				if got.Findings[i] != tt.want[i] {
+					t.Errorf("checkDrift() = %v, want %v", got.Findings, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateDriftReport(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	againstEnvironment, err := CreateDriftReport(&cli, &CommandOptionsDriftReport{Environment: "P", AgainstEnvironment: "B"}, nil)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(againstEnvironment.AppServers) != 1 || againstEnvironment.HasFindings() {
+		t.Errorf("Expecting one app server without findings, got %v", againstEnvironment.AppServers)
+	}
+
+	// when
+	expected := Snapshot{AppServers: []SnapshotAppServer{{Name: "Other", Applications: []SnapshotApplication{{"app1", "1.0"}}}}}
+	expectedVersions, err := CreateDriftReport(&cli, &CommandOptionsDriftReport{Environment: "P"}, &expected)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(expectedVersions.AppServers) != 2 || !expectedVersions.HasFindings() {
+		t.Fatalf("Expecting two app servers with findings, got %v", expectedVersions.AppServers)
+	}
+	//Test isn't listed in the expected versions, its versions aren't compared
+	assertString(t, "Test", expectedVersions.AppServers[1].AppServerName, "app server")
+	if len(expectedVersions.AppServers[1].Findings) != 0 {
+		t.Errorf("Expecting no findings of the app server not expected, got %v", expectedVersions.AppServers[1].Findings)
+	}
+}
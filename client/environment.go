@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//Environments is a collection of Environment
+type Environments []Environment
+
+//Environment is a Liima environment (context) with its parent domain
+type Environment struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`      //short code used by the deployments, example: "P"
+	NameAlias string `json:"nameAlias"` //full name, example: "PROD-CH"
+	Parent    string `json:"parent"`    //parent domain, example: "Prod"
+	ParentID  int    `json:"parentId"`
+}
+
+//Domains returns the parent domains of the environments in the order of the environments
+func (environments Environments) Domains() []string {
+	domains := []string{}
+	for _, environment := range environments {
+		known := false
+		for _, domain := range domains {
+			known = known || domain == environment.Parent
+		}
+		if !known {
+			domains = append(domains, environment.Parent)
+		}
+	}
+	return domains
+}
+
+//GetEnvironments returns all environments in the order of the server
+//The environments are read once per client, they are also used to validate the environments of the commands
+func GetEnvironments(cli *Cli) (Environments, error) {
+	if cli.Client.environments != nil {
+		return cli.Client.environments, nil
+	}
+
+	//Call rest client
+	environments := Environments{}
+	if err := cli.Client.DoRequest(http.MethodGet, "resources/./environments", nil, &environments); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	cli.Client.environments = environments
+	return environments, nil
+}
+
+//ResolveEnvironment returns the name of the environment given by its name or alias, ignoring case
+func ResolveEnvironment(cli *Cli, name string) (string, error) {
+	environments, err := GetEnvironments(cli)
+	if err != nil {
+		return "", err
+	}
+	name = strings.TrimSpace(name)
+	for _, environment := range environments {
+		if strings.EqualFold(environment.Name, name) || (environment.NameAlias != "" && strings.EqualFold(environment.NameAlias, name)) {
+			return environment.Name, nil
+		}
+	}
+
+	known := []string{}
+	for _, environment := range environments {
+		if environment.NameAlias != "" {
+			known = append(known, environment.Name+" ("+environment.NameAlias+")")
+		} else {
+			known = append(known, environment.Name)
+		}
+	}
+	return "", fmt.Errorf("unknown environment %s, want one of: %s", name, strings.Join(known, ", "))
+}
+
+//checkEnvironment replaces the environment given by name or alias with its name, an error is added to the errorList if it is missing or unknown
+func checkEnvironment(cli *Cli, errorList *[]string, environment *string, field string) {
+	if strings.TrimSpace(*environment) == "" {
+		*errorList = append(*errorList, "want "+field)
+		return
+	}
+	name, err := ResolveEnvironment(cli, *environment)
+	if err != nil {
+		*errorList = append(*errorList, fmt.Sprintf("want %s: %v", field, err))
+		return
+	}
+	*environment = name
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveEnvironment(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"P", "P"},
+		{"y", "Y"},
+		{"PROD-CH", "P"},
+		{"prod-dr", "Z"},
+	}
+	for _, tt := range tests {
+		// when
+		got, err := ResolveEnvironment(&cli, tt.name)
+
+		// then
+		if err != nil {
+			t.Fatalf("Excepting no error: %s", err)
+		}
+		assertString(t, tt.want, got, tt.name)
+	}
+}
+
+func TestValidateUnknownEnvironment(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	commandOptions := CommandOptionsPromoteDeployments{Environment: "PROD-CH", FromEnvironment: "Q"}
+
+	// when
+	err := commandOptions.validate(&cli)
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "want FromEnvironment: unknown environment Q, want one of: B (Build)") {
+		t.Fatalf("Expecting an unknown environment error, got %v", err)
+	}
+	assertString(t, "P", commandOptions.Environment, "environment")
+}
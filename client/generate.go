@@ -0,0 +1,181 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//GenerationResult is the result of the test generation of an app server on an environment
+type GenerationResult struct {
+	AppServerName string         `json:"appServerName"`
+	Release       string         `json:"release"`
+	Environment   string         `json:"environment"`
+	Files         GeneratedFiles `json:"files"`
+	Errors        []string       `json:"errors"` //errors of the template engine
+}
+
+//GeneratedFiles is a collection of GeneratedFile
+type GeneratedFiles []GeneratedFile
+
+//GeneratedFile is a file generated by the template engine for a node
+type GeneratedFile struct {
+	Node    string `json:"node"`
+	Path    string `json:"path"` //path relative to the node
+	Content string `json:"content"`
+}
+
+//sort.Interface
+func (slice GeneratedFiles) Len() int {
+	return len(slice)
+}
+
+func (slice GeneratedFiles) Less(i, j int) bool {
+	if slice[i].Node != slice[j].Node {
+		return slice[i].Node < slice[j].Node
+	}
+	return slice[i].Path < slice[j].Path
+}
+
+func (slice GeneratedFiles) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+//CommandOptionsGenerate used for the command options (flags)
+type CommandOptionsGenerate struct {
+	AppServer           string `json:"appServer"`
+	Environment         string `json:"environment"`
+	Release             string `json:"release"`             //last release of the app server if empty
+	DiffWithEnvironment string `json:"diffWithEnvironment"` //Generate also for this environment to compare the results
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsGenerate) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.AppServer != "", "want appServer")
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	if commandOption.DiffWithEnvironment != "" {
+		checkEnvironment(cli, &errorList, &commandOption.DiffWithEnvironment, "DiffWithEnvironment")
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Generate returns the files generated by the template engine for the app server on the environment,
+//and on the DiffWithEnvironment as second result if set
+func Generate(cli *Cli, commandOptions *CommandOptionsGenerate) ([]*GenerationResult, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+	release, err := resourceRelease(cli, commandOptions.AppServer, commandOptions.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	environments := []string{commandOptions.Environment}
+	if commandOptions.DiffWithEnvironment != "" {
+		environments = append(environments, commandOptions.DiffWithEnvironment)
+	}
+	results := []*GenerationResult{}
+	for _, environment := range environments {
+		result := &GenerationResult{}
+		generateURL := "resources/./resources/" + url.PathEscape(commandOptions.AppServer) + "/" + url.PathEscape(release) + "/generate?env=" + url.QueryEscape(environment)
+		if err := cli.Client.DoRequest(http.MethodGet, generateURL, nil, result); err != nil {
+			return nil, fmt.Errorf("Error in rest call: %v", err)
+		}
+		sort.Sort(result.Files)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//WriteGeneratedFiles writes the generated files to the directory, each node in its own directory
+func WriteGeneratedFiles(result *GenerationResult, dir string) error {
+	for _, file := range result.Files {
+		name, err := generatedFileName(dir, &file)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return fmt.Errorf("Couldn't create directory: %v", err)
+		}
+		if err := os.WriteFile(name, []byte(file.Content), 0644); err != nil {
+			return fmt.Errorf("Couldn't write generated file: %v", err)
+		}
+	}
+	return nil
+}
+
+//generatedFileName returns the name of the generated file in the directory, paths outside the directory are rejected
+func generatedFileName(dir string, file *GeneratedFile) (string, error) {
+	name, err := util.JoinInDir(dir, file.Node+"/"+file.Path)
+	if err != nil || strings.HasPrefix(file.Path, "/") {
+		return "", fmt.Errorf("Generated file %s of node %s is outside of the output directory", file.Path, file.Node)
+	}
+	return name, nil
+}
+
+//DiffGenerationResults returns the unified diff of the generated files of two results, an empty string if they are equal
+//The node names differ between environments, the files of the nodes with the same name without the environment suffix are compared:
+//node_t/bin/setenv.sh on T with node_p/bin/setenv.sh on P. The files of nodes without a counterpart are added or removed
+func DiffGenerationResults(from *GenerationResult, to *GenerationResult) string {
+	fromFiles, toFiles := from.Files.byNode(from.Environment), to.Files.byNode(to.Environment)
+	keys := []string{}
+	for key := range fromFiles {
+		keys = append(keys, key)
+	}
+	for key := range toFiles {
+		if _, found := fromFiles[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		fromName, toName, fromContent, toContent := "/dev/null", "/dev/null", "", ""
+		if file, found := fromFiles[key]; found {
+			fromName, fromContent = from.Environment+"/"+file.Node+"/"+file.Path, file.Content
+		}
+		if file, found := toFiles[key]; found {
+			toName, toContent = to.Environment+"/"+file.Node+"/"+file.Path, file.Content
+		}
+		sb.WriteString(util.UnifiedDiff(fromName, toName, fromContent, toContent))
+	}
+	return sb.String()
+}
+
+//byNode returns the files by the name of their node without the environment suffix and their path
+func (slice GeneratedFiles) byNode(environment string) map[string]*GeneratedFile {
+	files := map[string]*GeneratedFile{}
+	for i := range slice {
+		files[nodeWithoutEnvironment(slice[i].Node, environment)+"/"+slice[i].Path] = &slice[i]
+	}
+	return files
+}
+
+//nodeWithoutEnvironment returns the lower case node name without the suffix of the environment: "node_T" on T -> "node"
+func nodeWithoutEnvironment(node string, environment string) string {
+	node = strings.ToLower(node)
+	for _, separator := range []string{"_", "-", "."} {
+		if suffix := separator + strings.ToLower(environment); environment != "" && strings.HasSuffix(node, suffix) {
+			return strings.TrimSuffix(node, suffix)
+		}
+	}
+	return node
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFileName(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name    string //Name of the test
+		file    GeneratedFile
+		want    string //Wanted testresult
+		wantErr bool
+	}{
+		{"Test1", GeneratedFile{Node: "node01", Path: "conf/app.properties"}, filepath.Join("out", "node01", "conf", "app.properties"), false},
+		{"Test2", GeneratedFile{Node: "node01", Path: "../../etc/passwd"}, "", true},
+		{"Test3", GeneratedFile{Node: "node01", Path: "/etc/passwd"}, "", true},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generatedFileName("out", &tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generatedFileName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertString(t, tt.want, got, "file name")
+		})
+	}
+}
+
+func TestDiffGenerationResults(t *testing.T) {
+
+	// given
+	from := &GenerationResult{Environment: "T", Files: GeneratedFiles{
+		{Node: "node01_t", Path: "app.properties", Content: "heap=1g\n"},
+		{Node: "node02_t", Path: "app.properties", Content: "heap=1g\n"},
+	}}
+	to := &GenerationResult{Environment: "P", Files: GeneratedFiles{
+		{Node: "node02_p", Path: "app.properties", Content: "heap=2g\n"},
+	}}
+
+	// when
+	diff := DiffGenerationResults(from, to)
+
+	// then
+	want := "--- T/node01_t/app.properties\n+++ /dev/null\n@@ -1 +0,0 @@\n-heap=1g\n" +
+		"--- T/node02_t/app.properties\n+++ P/node02_p/app.properties\n@@ -1 +1 @@\n-heap=1g\n+heap=2g\n"
+	assertString(t, want, diff, "diff")
+}
@@ -5,8 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
+//FilterDateTimeFormat defines the date time format of the Liima deployment date filter
+const FilterDateTimeFormat = "02.01.2006 15:04"
+
+//DisplayDateTimeFormat defines the date time format used to print deployment dates
+const DisplayDateTimeFormat = "2006-01-02 15:04 MST"
+
 //Deployments is a collection of DeploymentResponse
 type Deployments []DeploymentResponse
 
@@ -22,7 +29,10 @@ type DeploymentResponse struct {
 		ApplicationName string `json:"applicationName"`
 		Version         string `json:"version"`
 	} `json:"appsWithVersion"`
-	DeploymentParameters []interface{} `json:"deploymentParameters"`
+	DeploymentParameters []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"deploymentParameters"`
 	EnvironmentName      string        `json:"environmentName"`
 	ReleaseName          string        `json:"releaseName"`
 	RuntimeName          string        `json:"runtimeName"`
@@ -31,6 +41,15 @@ type DeploymentResponse struct {
 	CancelUser           interface{}   `json:"cancelUser"`
 	NodeJobs             []interface{} `json:"nodeJobs"`
 	CancleUser           interface{}   `json:"cancleUser"`
+	ExecuteShakedownTest bool          `json:"executeShakedownTest"` //deployed with shakedown test, the result of the test is not part of the deployment
+	StatusMessage        string        `json:"statusMessage"`
+	Duration             time.Duration `json:"-"` //time from creating the deployment until its final state was seen while waiting, zero if not waited for
+	created              time.Time     //time the deployment was created by liimactl, zero if read from Liima
+}
+
+//DeploymentTime returns the deployment date in the local timezone
+func (deployment *DeploymentResponse) DeploymentTime() time.Time {
+	return time.Unix(0, deployment.DeploymentDate*int64(time.Millisecond)).In(time.Local)
 }
 
 //sort.Interface
@@ -55,7 +74,11 @@ type CommandOptionsGetDeployment struct {
 	TrackingID      int               `json:"trackingId"`
 	OnlyLatest      bool              `json:"onlyLatest"`
 	Filter          []DeploymentFilter
-	ID              []int //deployment id
+	ID              []int     //deployment id
+	DeploymentFrom  time.Time //only deployments with a deployment date at or after, ignored if zero
+	DeploymentTo    time.Time //only deployments with a deployment date before, ignored if zero
+	MaxResults      int       //max number of deployments returned (page size), all if 0
+	Offset          int       //number of deployments to skip (paging), used with MaxResults
 }
 
 // DeploymentFilter is a Liima deployment filter
@@ -87,6 +110,7 @@ const (
 	DeploymentStateFailed         DeploymentState = "failed"
 	DeploymentStateCanceled       DeploymentState = "canceled"
 	DeploymentStateRejected       DeploymentState = "rejected"
+	DeploymentStateExcluded       DeploymentState = "excluded" //client side only: not promoted, the promotion gate failed
 	DeploymentStateReadyForDeploy DeploymentState = "ready_for_deploy"
 	DeploymentStatePreDeploy      DeploymentState = "pre_deploy"
 	DeploymentStateProgress       DeploymentState = "progress"
@@ -115,6 +139,9 @@ func GetDeployment(cli *Cli, commandOptions *CommandOptionsGetDeployment) (Deplo
 	}
 	//Build URL
 	resturl += url.QueryEscape(string(b))
+	if commandOptions.MaxResults > 0 {
+		resturl += fmt.Sprintf("&maxResults=%d&offset=%d", commandOptions.MaxResults, commandOptions.Offset)
+	}
 
 	//Call rest client
 	if err := cli.Client.DoRequest(http.MethodGet, resturl, nil, &deployments); err != nil {
@@ -124,6 +151,18 @@ func GetDeployment(cli *Cli, commandOptions *CommandOptionsGetDeployment) (Deplo
 	return deployments, nil
 }
 
+//getLatestSuccessfulDeployments returns the latest successful deployment of each app server on an environment
+//If appServers is empty, the deployments of all app servers are returned
+func getLatestSuccessfulDeployments(cli *Cli, environment string, appServers []string) (Deployments, error) {
+	commandOptionsGet := CommandOptionsGetDeployment{}
+	commandOptionsGet.Environment = []string{environment}
+	commandOptionsGet.AppServer = appServers
+	commandOptionsGet.TrackingID = -1
+	commandOptionsGet.OnlyLatest = true
+	commandOptionsGet.DeploymentState = []DeploymentState{DeploymentStateSuccess}
+	return GetDeployment(cli, &commandOptionsGet)
+}
+
 func buildFilterFromOptions(commandOptions *CommandOptionsGetDeployment) []DeploymentFilter {
 	filters := []DeploymentFilter{}
 	for _, val := range commandOptions.AppName {
@@ -148,9 +187,24 @@ func buildFilterFromOptions(commandOptions *CommandOptionsGetDeployment) []Deplo
 	if commandOptions.TrackingID != -1 {
 		filters = append(filters, createEqFilter("Tracking Id", commandOptions.TrackingID))
 	}
+	if !commandOptions.DeploymentFrom.IsZero() {
+		filters = append(filters, createDateFilter(Gte, commandOptions.DeploymentFrom))
+	}
+	if !commandOptions.DeploymentTo.IsZero() {
+		filters = append(filters, createDateFilter(Lt, commandOptions.DeploymentTo))
+	}
 	return filters
 }
 
+//createDateFilter creates a deployment date filter, the date is sent in the local timezone
+func createDateFilter(comp DeploymentFilterComp, value time.Time) DeploymentFilter {
+	return DeploymentFilter{
+		Name: "Deployment date",
+		Comp: comp,
+		Val:  value.In(time.Local).Format(FilterDateTimeFormat),
+	}
+}
+
 func createEqFilter(name string, value interface{}) DeploymentFilter {
 	return DeploymentFilter{
 		Name: name,
@@ -5,6 +5,7 @@ import (
 	"github.com/Bplotka/go-httpt/rt"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetDeploymentWithMockedHttpClient(t *testing.T) {
@@ -27,6 +28,29 @@ func TestGetDeploymentWithMockedHttpClient(t *testing.T) {
 	assertString(t, "252734", testResponse.Value, "value")
 }
 
+func TestBuildFilterFromOptionsWithDeploymentDates(t *testing.T) {
+
+	// given
+	commandOptions := CommandOptionsGetDeployment{
+		TrackingID:     -1,
+		DeploymentFrom: time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local),
+		DeploymentTo:   time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local),
+	}
+
+	// when
+	filters := buildFilterFromOptions(&commandOptions)
+
+	// then
+	if len(filters) != 2 {
+		t.Fatalf("Expecting 2 filters, got %v", filters)
+	}
+	assertString(t, "Deployment date", filters[0].Name, "name")
+	assertString(t, string(Gte), string(filters[0].Comp), "comp")
+	assertString(t, "17.10.2026 00:00", filters[0].Val.(string), "val")
+	assertString(t, string(Lt), string(filters[1].Comp), "comp")
+	assertString(t, "18.10.2026 00:00", filters[1].Val.(string), "val")
+}
+
 func assertString(t *testing.T, expected string, result string, fieldName string) {
 	if result != expected {
 		t.Errorf("%s doesn't seem correct, got %s but expected %s.", fieldName, result, expected)
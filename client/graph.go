@@ -0,0 +1,334 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//Output formats of a graph
+const (
+	GraphFormatDot     = "dot"
+	GraphFormatMermaid = "mermaid"
+	GraphFormatJSON    = "json"
+)
+
+//GraphTypeHost is the type of the host nodes and of the edges from the app servers to their hosts
+const GraphTypeHost = "HOST"
+
+//Graph is the dependency graph of the resources and hosts on an environment
+type Graph struct {
+	Environment string      `json:"environment"`
+	Nodes       []GraphNode `json:"nodes"`
+	Edges       []GraphEdge `json:"edges"`
+}
+
+//GraphNode is a resource or host of a graph
+type GraphNode struct {
+	ID      string `json:"id"` //name and release of a resource ("aps@RL-19.04"), GraphTypeHost and name of a host ("HOST:host01")
+	Name    string `json:"name"`
+	Type    string `json:"type"` //Resource type or GraphTypeHost
+	Release string `json:"release,omitempty"`
+}
+
+//GraphEdge is a relation of a graph
+type GraphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Type  string `json:"type"`            //CONSUMED, PROVIDED or GraphTypeHost
+	Label string `json:"label,omitempty"` //identifier of the relation or node of the host
+}
+
+//CommandOptionsGraph used for the command options (flags)
+type CommandOptionsGraph struct {
+	Environment string   `json:"environment"`
+	AppServer   []string `json:"appServer"` //App server patterns ("aps_*" or "re:^aps_.*") of the graph roots, all if empty
+	Format      string   `json:"format"`
+}
+
+//CommandOptionsGetRelation used for the command options (flags)
+type CommandOptionsGetRelation struct {
+	Resource string `json:"resource"`
+	Release  string `json:"release"` //last release of the resource if empty
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsGraph) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	for _, err := range util.ValidatePatterns(commandOption.AppServer) {
+		errorList = append(errorList, err.Error())
+	}
+	formats := []string{GraphFormatDot, GraphFormatMermaid, GraphFormatJSON}
+	util.Check(&errorList, util.Contains(commandOption.Format, formats), "want format %s, got %s", strings.Join(formats, ", "), commandOption.Format)
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsGetRelation) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.Resource != "", "want resource")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetRelations returns a release of a resource with its relations
+func GetRelations(cli *Cli, commandOptions *CommandOptionsGetRelation) (*ResourceDetail, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+	release, err := resourceRelease(cli, commandOptions.Resource, commandOptions.Release)
+	if err != nil {
+		return nil, err
+	}
+	return GetResourceDetail(cli, commandOptions.Resource, release)
+}
+
+//graphResource is a release of a resource to walk in the graph
+type graphResource struct {
+	name    string
+	release string
+}
+
+//graphNodeID returns the id of the node of a resource release, the releases of a resource are different nodes
+func graphNodeID(name string, release string) string {
+	if release == "" {
+		return name
+	}
+	return name + "@" + release
+}
+
+//GetGraph walks from the releases of the app servers deployed on the environment through the relations of the resources and returns them with the hosts of the app servers as graph
+func GetGraph(cli *Cli, commandOptions *CommandOptionsGraph) (*Graph, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+	resources, err := GetResources(cli, &CommandOptionsGetResource{})
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := getLatestSuccessfulDeployments(cli, commandOptions.Environment, nil)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := GetReleases(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	//Roots are the releases of the app servers deployed on the environment
+	queue := graphRoots(deployments, resources, releases, commandOptions.AppServer)
+	appServers := []string{}
+	for _, root := range queue {
+		appServers = append(appServers, root.name)
+	}
+	if len(appServers) == 0 {
+		return nil, fmt.Errorf("No app server deployed on environment %s", commandOptions.Environment)
+	}
+
+	//Walk the relations breadth first, each resource release once
+	details := []ResourceDetail{}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if visited[graphNodeID(next.name, next.release)] {
+			continue
+		}
+		visited[graphNodeID(next.name, next.release)] = true
+
+		detail, err := GetResourceDetail(cli, next.name, next.release)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *detail)
+		for _, relation := range detail.Relations {
+			queue = append(queue, graphResource{relation.RelatedResourceName, relation.RelatedResourceRelease})
+		}
+	}
+
+	hostnames, err := GetHostname(cli, &CommandOptionsHostName{Environment: []string{commandOptions.Environment}, AppServer: appServers})
+	if err != nil {
+		return nil, err
+	}
+	return newGraph(commandOptions.Environment, resources, details, hostnames), nil
+}
+
+//graphRoots returns the app servers with their release of the latest successful deployments matching the patterns (all if empty), sorted by name
+//The last release of the app server by installation date is used, if the deployment has no release
+func graphRoots(deployments Deployments, resources Resources, releases Releases, patterns []string) []graphResource {
+	lastReleases := map[string]string{}
+	for i := range resources {
+		if resources[i].Type == ResourceTypeApplicationServer {
+			lastReleases[resources[i].Name] = resources[i].lastRelease(releases)
+		}
+	}
+
+	roots := []graphResource{}
+	added := map[string]bool{}
+	for _, deployment := range deployments {
+		if added[deployment.AppServerName] {
+			continue
+		}
+		if _, found := util.FindPattern(deployment.AppServerName, patterns); len(patterns) > 0 && !found {
+			continue
+		}
+		release := deployment.ReleaseName
+		if release == "" {
+			release = lastReleases[deployment.AppServerName]
+		}
+		if release == "" {
+			continue
+		}
+		added[deployment.AppServerName] = true
+		roots = append(roots, graphResource{deployment.AppServerName, release})
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].name < roots[j].name })
+	return roots
+}
+
+//newGraph returns the graph of the resource releases with their relations and the hosts of the app servers
+//The hosts are related to the first release of their app server in the details, the release deployed on the environment
+func newGraph(environment string, resources Resources, details []ResourceDetail, hostnames Hostnames) *Graph {
+	graph := &Graph{Environment: environment, Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	types := map[string]string{}
+	for _, resource := range resources {
+		types[resource.Name] = resource.Type
+	}
+
+	nodes := map[string]bool{}
+	addNode := func(node GraphNode) {
+		if !nodes[node.ID] {
+			nodes[node.ID] = true
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+	resourceIDs := map[string]string{}
+	for _, detail := range details {
+		id := graphNodeID(detail.Name, detail.Release)
+		if _, found := resourceIDs[detail.Name]; !found {
+			resourceIDs[detail.Name] = id
+		}
+		addNode(GraphNode{ID: id, Name: detail.Name, Type: types[detail.Name], Release: detail.Release})
+	}
+	for _, detail := range details {
+		for _, relation := range detail.Relations {
+			relatedID := graphNodeID(relation.RelatedResourceName, relation.RelatedResourceRelease)
+			addNode(GraphNode{ID: relatedID, Name: relation.RelatedResourceName, Type: types[relation.RelatedResourceName], Release: relation.RelatedResourceRelease})
+			edge := GraphEdge{From: graphNodeID(detail.Name, detail.Release), To: relatedID, Type: relation.Type}
+			if relation.Identifier != relation.RelatedResourceName {
+				edge.Label = relation.Identifier
+			}
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+	for _, hostname := range hostnames {
+		appServerID, found := resourceIDs[hostname.AppServer]
+		if hostname.Host == "" || !found {
+			continue
+		}
+		addNode(GraphNode{ID: GraphTypeHost + ":" + hostname.Host, Name: hostname.Host, Type: GraphTypeHost})
+		graph.Edges = append(graph.Edges, GraphEdge{From: appServerID, To: GraphTypeHost + ":" + hostname.Host, Type: GraphTypeHost, Label: hostname.Node})
+	}
+	return graph
+}
+
+//WriteGraph writes the graph in the format dot (Graphviz), mermaid or json
+func WriteGraph(w io.Writer, graph *Graph, format string) error {
+	switch format {
+	case GraphFormatDot:
+		return writeDot(w, graph)
+	case GraphFormatMermaid:
+		return writeMermaid(w, graph)
+	case GraphFormatJSON:
+		data, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	return fmt.Errorf("Unknown graph format %s", format)
+}
+
+//label returns the name and release of a node
+func (node *GraphNode) label(separator string) string {
+	if node.Release == "" {
+		return node.Name
+	}
+	return node.Name + separator + node.Release
+}
+
+//edgeLabel returns the type and label of an edge
+func (edge *GraphEdge) edgeLabel() string {
+	if edge.Label == "" {
+		return edge.Type
+	}
+	return edge.Type + " " + edge.Label
+}
+
+//writeDot writes the graph in the dot language of Graphviz
+func writeDot(w io.Writer, graph *Graph) error {
+	shapes := map[string]string{ResourceTypeApplicationServer: "box", ResourceTypeApplication: "ellipse", ResourceTypeNode: "component", GraphTypeHost: "cylinder"}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %q {\n  rankdir=LR;\n", graph.Environment)
+	for _, node := range graph.Nodes {
+		shape, found := shapes[node.Type]
+		if !found {
+			shape = "note"
+		}
+		fmt.Fprintf(&sb, "  %q [label=%q, shape=%s];\n", node.ID, node.label("\n"), shape)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.edgeLabel())
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+//writeMermaid writes the graph as mermaid flowchart
+func writeMermaid(w io.Writer, graph *Graph) error {
+	shapes := map[string]string{ResourceTypeApplicationServer: "[%s]", ResourceTypeApplication: "(%s)", ResourceTypeNode: "[[%s]]", GraphTypeHost: "[(%s)]"}
+	escape := strings.NewReplacer(`"`, "#quot;", "|", "#124;")
+
+	//Mermaid ids are the index of the nodes, the names may contain any character
+	ids := map[string]string{}
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for i, node := range graph.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		shape, found := shapes[node.Type]
+		if !found {
+			shape = "[%s]"
+		}
+		fmt.Fprintf(&sb, "  %s"+shape+"\n", ids[node.ID], `"`+escape.Replace(node.label("<br/>"))+`"`)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&sb, "  %s -->|%s| %s\n", ids[edge.From], escape.Replace(edge.edgeLabel()), ids[edge.To])
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
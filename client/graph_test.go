@@ -0,0 +1,87 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewGraphWithHosts(t *testing.T) {
+
+	// given
+	resources := Resources{{Name: "aps", Type: ResourceTypeApplicationServer}, {Name: "app", Type: ResourceTypeApplication}}
+	details := []ResourceDetail{
+		{Name: "aps", Release: "RL-19.04", Relations: []ResourceRelation{{RelatedResourceName: "app", RelatedResourceRelease: "RL-19.04", Identifier: "app_1", Type: "CONSUMED"}}},
+		{Name: "app", Release: "RL-19.04"},
+	}
+	hostnames := Hostnames{{Host: "host01", AppServer: "aps", Node: "node01"}, {Host: "host02", AppServer: "other"}}
+
+	// when
+	graph := newGraph("T", resources, details, hostnames)
+
+	// then
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 {
+		t.Fatalf("Expecting three nodes and two edges, got %v", graph)
+	}
+	assertString(t, "app_1", graph.Edges[0].Label, "relation label")
+	assertString(t, "aps@RL-19.04", graph.Edges[1].From, "host edge from app server release")
+	assertString(t, GraphTypeHost+":host01", graph.Edges[1].To, "host edge")
+	assertString(t, "node01", graph.Edges[1].Label, "host edge label")
+}
+
+func TestGraphRootsOfDeployedAppServers(t *testing.T) {
+
+	// given
+	resources := Resources{
+		{Name: "aps", Type: ResourceTypeApplicationServer, Releases: []ResourceRelease{{Release: "RL-18.10"}, {Release: "RL-19.04"}}},
+		{Name: "aps_batch", Type: ResourceTypeApplicationServer, Releases: []ResourceRelease{{Release: "RL-19.04"}, {Release: "RL-18.10"}}},
+		{Name: "aps_notdeployed", Type: ResourceTypeApplicationServer, Releases: []ResourceRelease{{Release: "RL-19.04"}}},
+	}
+	deployments := Deployments{
+		newTestDeployment(1, 0, DeploymentStateSuccess, "app", "1.0"),
+		newTestDeployment(2, 0, DeploymentStateSuccess, "batch", "1.0"),
+		newTestDeployment(3, 0, DeploymentStateSuccess, "other", "1.0"),
+	}
+	deployments[0].AppServerName, deployments[0].ReleaseName = "aps_batch", ""
+	deployments[1].AppServerName, deployments[1].ReleaseName = "aps", "RL-18.10"
+	deployments[2].AppServerName, deployments[2].ReleaseName = "other", "RL-19.04"
+
+	// when
+	releases := Releases{{Name: "RL-18.10", InstallationInProductionAt: 1539604800000}, {Name: "RL-19.04", InstallationInProductionAt: 1555329600000}}
+	roots := graphRoots(deployments, resources, releases, []string{"aps*"})
+
+	// then
+	if len(roots) != 2 {
+		t.Fatalf("Expecting two roots, got %v", roots)
+	}
+	assertString(t, "aps RL-18.10", roots[0].name+" "+roots[0].release, "deployed release")
+	assertString(t, "aps_batch RL-19.04", roots[1].name+" "+roots[1].release, "last release by installation date")
+}
+
+func TestNewGraphWithReleasesOfAResource(t *testing.T) {
+
+	// given
+	resources := Resources{{Name: "aps", Type: ResourceTypeApplicationServer}, {Name: "aps_batch", Type: ResourceTypeApplicationServer}, {Name: "app", Type: ResourceTypeApplication}}
+	details := []ResourceDetail{
+		{Name: "aps", Release: "RL-19.04", Relations: []ResourceRelation{{RelatedResourceName: "app", RelatedResourceRelease: "RL-19.04", Identifier: "app", Type: "CONSUMED"}}},
+		{Name: "aps_batch", Release: "RL-18.10", Relations: []ResourceRelation{{RelatedResourceName: "app", RelatedResourceRelease: "RL-18.10", Identifier: "app|batch", Type: "CONSUMED"}}},
+		{Name: "app", Release: "RL-19.04"},
+		{Name: "app", Release: "RL-18.10"},
+	}
+
+	// when
+	graph := newGraph("T", resources, details, Hostnames{})
+	var sb strings.Builder
+	err := WriteGraph(&sb, graph, GraphFormatMermaid)
+
+	// then
+	if len(graph.Nodes) != 4 || len(graph.Edges) != 2 {
+		t.Fatalf("Expecting four nodes and two edges, got %v", graph)
+	}
+	assertString(t, "app@RL-18.10", graph.Edges[1].To, "related release")
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if !strings.Contains(sb.String(), "n1 -->|CONSUMED app#124;batch| n3") {
+		t.Errorf("Expecting an escaped edge label, got %s", sb.String())
+	}
+}
@@ -26,7 +26,7 @@ func NewMockClient(config *Config) (*Client, error) {
 
 	//Set Server with handlers
 	mux := serverMuxHandler()
-	ts := httptest.NewServer(mux)
+	ts := httptest.NewServer(cleanPathHandler(mux))
 	//set localhost in config
 	config.Host = ts.URL + "/"
 
@@ -47,6 +47,14 @@ func NewMockClientWithCustomHttpClient(client *http.Client) *Client {
 	}
 }
 
+//cleanPathHandler removes the "/./" of the rest urls, the ServeMux would redirect them and the redirect loses the method and body
+func cleanPathHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Path = strings.Replace(r.URL.Path, "/./", "/", -1)
+		handler.ServeHTTP(w, r)
+	})
+}
+
 //Mux Http handlers
 func serverMuxHandler() *http.ServeMux {
 	r := http.NewServeMux()
@@ -60,6 +68,20 @@ func serverMuxHandler() *http.ServeMux {
 	//Hostname test handler
 	r.HandleFunc("/resources/hostNames", listHostnameHandler)
 
+	//Resource test handlers
+	r.HandleFunc("/resources/resources", listResourceHandler)
+	r.HandleFunc("/resources/resources/", getResourceHandler)
+
+	//Release test handlers
+	r.HandleFunc("/resources/releases", listReleaseHandler)
+	r.HandleFunc("/resources/releases/", getReleaseHandler)
+
+	//Resource type test handler
+	r.HandleFunc("/resources/resourceTypes/", resourceTypeHandler)
+
+	//Environment test handler
+	r.HandleFunc("/resources/environments", listEnvironmentHandler)
+
 	return r
 }
 
@@ -141,6 +163,228 @@ func listHostnameHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(hostname)
 }
 
+//mockResources are the resources of the resource test handlers
+var mockResources = Resources{
+	{ID: 1, Name: "Test", Type: ResourceTypeApplicationServer, Releases: []ResourceRelease{{ID: 10, Release: "RL-18.10"}, {ID: 11, Release: "RL-19.04"}}},
+	{ID: 2, Name: "testapp", Type: ResourceTypeApplication, Releases: []ResourceRelease{{ID: 20, Release: "RL-19.04"}}},
+	{ID: 3, Name: "node01", Type: ResourceTypeNode, Releases: []ResourceRelease{{ID: 30, Release: "RL-18.10"}}},
+}
+
+//Resource list test handler, filtered by the query parameter type
+func listResourceHandler(w http.ResponseWriter, r *http.Request) {
+
+	response := Resources{}
+	for _, resource := range mockResources {
+		if resourceType := r.URL.Query().Get("type"); resourceType == "" || resourceType == resource.Type {
+			response = append(response, resource)
+		}
+	}
+	writeJSON(w, response)
+}
+
+//Resource test handler: /resources/resources/{name} and /resources/resources/{name}/{release}, properties, generate and templates below the release
+func getResourceHandler(w http.ResponseWriter, r *http.Request) {
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/resources/resources/"), "/")
+	if len(parts) > 2 && parts[2] == "generate" {
+		generateHandler(w, r)
+		return
+	}
+	if len(parts) > 2 && parts[2] == "templates" {
+		templateHandler(w, r, mockTemplates[parts[0]], parts[3:])
+		return
+	}
+	if len(parts) > 2 {
+		propertyHandler(w, r, parts[2:])
+		return
+	}
+	for _, resource := range mockResources {
+		if resource.Name != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			writeJSON(w, resource)
+			return
+		}
+		if resource.HasRelease(parts[1]) {
+			detail := ResourceDetail{ID: resource.ID, Name: resource.Name, Type: resource.Type, Release: parts[1], Relations: []ResourceRelation{}}
+			if resource.Name == "Test" {
+				detail.Relations = append(detail.Relations,
+					ResourceRelation{RelatedResourceName: "testapp", RelatedResourceRelease: "RL-19.04", Identifier: "testapp", Type: "CONSUMED"},
+					ResourceRelation{RelatedResourceName: "node01", RelatedResourceRelease: "RL-18.10", Identifier: "node01", Type: "CONSUMED"})
+			}
+			writeJSON(w, detail)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+//mockReleases are the releases of the release test handlers, installed at noon UTC to get the same day in all timezones
+var mockReleases = Releases{
+	{ID: 1, Name: "RL-18.10", InstallationInProductionAt: 1539604800000},                                           //2018-10-15
+	{ID: 2, Name: "RL-19.04", InstallationInProductionAt: 1555329600000, MainRelease: true},                        //2019-04-15
+	{ID: 3, Name: "RL-99.01", InstallationInProductionAt: 4071556800000, Description: "Release of the far future"}, //2099-01-08
+}
+
+//Release list test handler, POST creates a release
+func listReleaseHandler(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method == http.MethodPost {
+		release := Release{}
+		if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		release.ID = len(mockReleases) + 1
+		writeJSON(w, release)
+		return
+	}
+	writeJSON(w, mockReleases)
+}
+
+//Release test handler: /resources/releases/{name}
+func getReleaseHandler(w http.ResponseWriter, r *http.Request) {
+
+	name := strings.TrimPrefix(r.URL.Path, "/resources/releases/")
+	for _, release := range mockReleases {
+		if release.Name == name {
+			writeJSON(w, release)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+//mockEnvironments are the environments of the environment test handler
+var mockEnvironments = Environments{
+	{ID: 1, Name: "B", NameAlias: "Build", Parent: "Dev", ParentID: 100},
+	{ID: 2, Name: "D", NameAlias: "Development", Parent: "Dev", ParentID: 100},
+	{ID: 3, Name: "I", NameAlias: "Integration", Parent: "Test", ParentID: 101},
+	{ID: 4, Name: "T", NameAlias: "Test", Parent: "Test", ParentID: 101},
+	{ID: 5, Name: "Y", NameAlias: "Acceptance", Parent: "Test", ParentID: 101},
+	{ID: 6, Name: "P", NameAlias: "PROD-CH", Parent: "Prod", ParentID: 102},
+	{ID: 7, Name: "Z", NameAlias: "PROD-DR", Parent: "Prod", ParentID: 102},
+}
+
+//Environment list test handler
+func listEnvironmentHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, mockEnvironments)
+}
+
+//mockProperties are the properties of the property test handler, the relation properties are of any relation
+var mockProperties = Properties{
+	{Name: "jvm.heap.max", Value: "2048m", Context: "T", ReplacedValue: "1024m"},
+	{Name: "jvm.heap.min", Value: "512m", Context: "Global"},
+}
+var mockRelationProperties = Properties{
+	{Name: "url", Value: "http://testapp", Context: "Test"},
+}
+
+//Property test handler: .../{release}/properties[/{name}] and .../{release}/relations/{name}/{release}/properties[/{name}]
+//GET lists the properties, PUT sets and DELETE unsets a property
+func propertyHandler(w http.ResponseWriter, r *http.Request, parts []string) {
+
+	properties := mockProperties
+	if parts[0] == "relations" && len(parts) > 3 {
+		properties = mockRelationProperties
+		parts = parts[3:]
+	}
+	if parts[0] != "properties" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if len(parts) == 1 {
+		writeJSON(w, properties)
+		return
+	}
+	if _, found := properties.findProperty(parts[1]); !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method == http.MethodPut {
+		property := Property{}
+		if err := json.NewDecoder(r.Body).Decode(&property); err != nil || property.Name != parts[1] {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//Generate test handler, the jvm heap size and the node name depend on the environment
+func generateHandler(w http.ResponseWriter, r *http.Request) {
+
+	environment := r.URL.Query().Get("env")
+	heap := "1024m"
+	if environment == "P" {
+		heap = "2048m"
+	}
+	result := GenerationResult{AppServerName: "Test", Release: "RL-19.04", Environment: environment, Files: GeneratedFiles{
+		{Node: "node_" + strings.ToLower(environment), Path: "bin/setenv.sh", Content: "#!/bin/sh\nJAVA_OPTS=-Xmx" + heap + "\nexport JAVA_OPTS\n"},
+		{Node: "node_" + strings.ToLower(environment), Path: "conf/app.properties", Content: "app.name=testapp\n"},
+	}}
+	writeJSON(w, result)
+}
+
+//mockTemplates are the templates of the template test handler by resource or resource type
+var mockTemplates = map[string]Templates{
+	"Test": {
+		{ID: 1, Name: "app.properties", TargetPath: "conf/app.properties", FileContent: "app.name=${name}\n"},
+		{ID: 2, Name: "setenv.sh", TargetPath: "bin/setenv.sh", TargetPlatforms: []string{"EAP 7"}, FileContent: "#!/bin/sh\nJAVA_OPTS=-Xmx${heap}\n"},
+	},
+	ResourceTypeApplicationServer: {
+		{ID: 3, Name: "log4j.xml", TargetPath: "conf/log4j.xml", FileContent: "<log4j/>\n"},
+	},
+}
+
+//Resource type test handler: /resources/resourceTypes/{type}/templates
+func resourceTypeHandler(w http.ResponseWriter, r *http.Request) {
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/resources/resourceTypes/"), "/")
+	if len(parts) < 2 || parts[1] != "templates" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	templateHandler(w, r, mockTemplates[parts[0]], parts[2:])
+}
+
+//Template test handler: .../templates[/{name}], GET returns the templates, PUT creates or updates a template
+func templateHandler(w http.ResponseWriter, r *http.Request, templates Templates, parts []string) {
+
+	if len(parts) == 0 {
+		writeJSON(w, templates)
+		return
+	}
+	if r.Method == http.MethodPut {
+		template := Template{}
+		if err := json.NewDecoder(r.Body).Decode(&template); err != nil || template.Name != parts[0] {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if template.ID == 0 {
+			template.ID = 100
+		}
+		writeJSON(w, template)
+		return
+	}
+	if template, found := templates.findTemplate(parts[0]); found {
+		writeJSON(w, template)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+//writeJSON writes the response as JSON
+func writeJSON(w http.ResponseWriter, response interface{}) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Write(data)
+}
+
 //DoRequest set up a json for the given url and calls the llima client.
 //Method: http.MethodX
 //URL: Resturl
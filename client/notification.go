@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+)
+
+//SignatureHeader is the HTTP header with the HMAC-SHA256 signature of the webhook body
+const SignatureHeader = "X-Liimactl-Signature"
+
+//Notification events
+const (
+	NotificationEventFinished     = "finished"     //all deployments of a command are finished
+	NotificationEventStateChanged = "stateChanged" //the state of a deployment changed while waiting
+)
+
+//webhookRetryDelay is the delay before the first retry, doubled on each retry
+var webhookRetryDelay = time.Second
+
+// NotificationConfig contains the notifications sent on deployment results
+type NotificationConfig struct {
+	// Webhooks called with the deployment summary
+	Webhooks []WebhookConfig
+	// Email with the summary of a promote
+	Email EmailConfig
+}
+
+// WebhookConfig contains the settings of a webhook
+type WebhookConfig struct {
+	// URL of the webhook, called with POST
+	URL string
+	// Template of the body (text/template with a NotificationPayload), default is the payload as JSON
+	// The template function "json" quotes a value for JSON bodies, example: {"text": {{json .Summary}}}
+	Template string
+	// Content type of the body, default is "application/json"
+	ContentType string
+	// Secret used to sign the body with HMAC-SHA256 in the header X-Liimactl-Signature: sha256=<hex>
+	Secret string
+	// Retries on network errors and server errors (5xx, 429), default is 3
+	Retries *int
+	// Send a notification on each state change of a deployment while waiting
+	OnStateChange bool
+}
+
+// Validate the notification config
+func (config *NotificationConfig) Validate() []error {
+	validationErrors := make([]error, 0)
+	for _, webhook := range config.Webhooks {
+		if _, err := url.ParseRequestURI(webhook.URL); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("webhook url is not valid: %s", err))
+		}
+		if _, err := webhook.template(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("webhook template of %s is not valid: %s", webhook.URL, err))
+		}
+		if webhook.Retries != nil && *webhook.Retries < 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("webhook retries of %s can't be negative", webhook.URL))
+		}
+	}
+	validationErrors = append(validationErrors, config.Email.Validate()...)
+	return validationErrors
+}
+
+//NotificationPayload is the summary of the deployments sent to the webhooks
+type NotificationPayload struct {
+	Event       string                   `json:"event"`
+	Command     string                   `json:"command,omitempty"`
+	Environment string                   `json:"environment"`
+	Success     bool                     `json:"success"`
+	Summary     string                   `json:"summary"`
+	Error       string                   `json:"error,omitempty"` //error of the command, example: timeout while waiting
+	Time        string                   `json:"time"`
+	Deployments []NotificationDeployment `json:"deployments"`
+}
+
+//NotificationDeployment is a deployment of the NotificationPayload
+type NotificationDeployment struct {
+	ID            int                   `json:"id"`
+	AppServerName string                `json:"appServerName"`
+	Environment   string                `json:"environment"`
+	Release       string                `json:"release"`
+	State         DeploymentState       `json:"state"`
+	Applications  []SnapshotApplication `json:"applications"`
+}
+
+//newNotificationPayload creates the payload of an event, the command is empty on state changes
+func newNotificationPayload(event string, command string, environment string, deployments Deployments) NotificationPayload {
+	payload := NotificationPayload{
+		Event:       event,
+		Command:     command,
+		Environment: environment,
+		Success:     true,
+		Time:        time.Now().Format(time.RFC3339),
+		Deployments: []NotificationDeployment{},
+	}
+	states := map[DeploymentState]int{}
+	stateOrder := []DeploymentState{}
+	for _, deployment := range deployments {
+		notificationDeployment := NotificationDeployment{
+			ID:            deployment.ID,
+			AppServerName: deployment.AppServerName,
+			Environment:   deployment.EnvironmentName,
+			Release:       deployment.ReleaseName,
+			State:         deployment.State,
+			Applications:  newSnapshotAppServer(&deployment).Applications,
+		}
+		payload.Deployments = append(payload.Deployments, notificationDeployment)
+		payload.Success = payload.Success && deployment.State != DeploymentStateFailed
+		if states[deployment.State] == 0 {
+			stateOrder = append(stateOrder, deployment.State)
+		}
+		states[deployment.State]++
+	}
+
+	payload.Summary = fmt.Sprintf("%d deployment(s) on environment %s", len(deployments), environment)
+	for i, state := range stateOrder {
+		separator := ", "
+		if i == 0 {
+			separator = ": "
+		}
+		payload.Summary += fmt.Sprintf("%s%d %s", separator, states[state], state)
+	}
+	return payload
+}
+
+//notifyFinished notifies the configured receivers that the deployments of a command are finished
+//or that the command stopped with an error after creating deployments, the deployments have their last known state
+func notifyFinished(cli *Cli, command string, environment string, deployments Deployments, err error) {
+	payload := newNotificationPayload(NotificationEventFinished, command, environment, deployments)
+	if err != nil {
+		payload.Success = false
+		payload.Error = err.Error()
+		payload.Summary += ", error: " + err.Error()
+	}
+	for _, webhook := range cli.Client.config.Notification.Webhooks {
+		if err := webhook.send(&payload); err != nil {
+			log.Printf("Error on notification to webhook %s: %v", webhook.URL, err)
+		}
+	}
+}
+
+//notifyStateChange notifies the configured receivers with OnStateChange about the changed state of a deployment
+func notifyStateChange(cli *Cli, deployment *DeploymentResponse) {
+	payload := newNotificationPayload(NotificationEventStateChanged, "", deployment.EnvironmentName, Deployments{*deployment})
+	for _, webhook := range cli.Client.config.Notification.Webhooks {
+		if !webhook.OnStateChange {
+			continue
+		}
+		if err := webhook.send(&payload); err != nil {
+			log.Printf("Error on notification to webhook %s: %v", webhook.URL, err)
+		}
+	}
+}
+
+//notifyStateChanges notifies about each deployment whose state differs from the given states and updates the states
+func notifyStateChanges(cli *Cli, deployments Deployments, states map[int]DeploymentState) {
+	for i := range deployments {
+		if state, ok := states[deployments[i].ID]; ok && state != deployments[i].State {
+			notifyStateChange(cli, &deployments[i])
+		}
+		states[deployments[i].ID] = deployments[i].State
+	}
+}
+
+//body returns the body of the webhook, the payload as JSON or the rendered template
+func (webhook *WebhookConfig) body(payload *NotificationPayload) ([]byte, error) {
+	if webhook.Template == "" {
+		return json.Marshal(payload)
+	}
+	tmpl, err := webhook.template()
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+//template parses the template of the body
+func (webhook *WebhookConfig) template() (*template.Template, error) {
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}).Parse(webhook.Template)
+}
+
+//send posts the payload to the webhook and retries on network and server errors
+func (webhook *WebhookConfig) send(payload *NotificationPayload) error {
+	body, err := webhook.body(payload)
+	if err != nil {
+		return err
+	}
+	contentType := webhook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	retries := 3
+	if webhook.Retries != nil {
+		retries = *webhook.Retries
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	delay := webhookRetryDelay
+	for i := 0; ; i++ {
+		req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", contentType)
+		if webhook.Secret != "" {
+			req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+		}
+
+		resp, err := httpClient.Do(req)
+		retry := err != nil
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+				return nil
+			}
+			err = fmt.Errorf("response status %s", resp.Status)
+			retry = resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		}
+		if !retry || i >= retries {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+//Sign returns the HMAC-SHA256 signature of the body in the format of the header X-Liimactl-Signature: sha256=<hex>
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
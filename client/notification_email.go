@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//defaultEmailSubject is the subject template of the promote summary
+const defaultEmailSubject = `Liima promote {{.FromEnvironment}} to {{.Environment}}: {{if .Error}}error{{else if .Success}}success{{else}}failed{{end}}`
+
+//defaultEmailTemplate is the body template of the promote summary
+const defaultEmailTemplate = `Promote from environment {{.FromEnvironment}} to {{.Environment}}
+{{.Summary}}
+{{- if .Error}}
+Error: {{.Error}}
+{{- end}}
+{{range .Deployments}}
+{{.AppServerName}}: {{.State}}, release {{.Release}}
+{{- range .Applications}}
+  {{.Name}} {{.Version}}
+{{- end}}
+{{end}}`
+
+//emailTimeout limits the connection to the SMTP server, an unreachable server mustn't block the end of a promote
+var emailTimeout = 30 * time.Second
+
+// EmailConfig contains the settings of the summary e-mail sent at the end of a promote
+type EmailConfig struct {
+	// Host of the SMTP server, no e-mail is sent if empty
+	Host string
+	// Port of the SMTP server, default is 25
+	Port int
+	// Upgrade the connection with STARTTLS
+	StartTLS bool
+	// SMTP server requires PLAIN authentication (only over TLS or to localhost)
+	Username string
+	Password string
+	// Sender address
+	From string
+	// Recipient addresses
+	To []string
+	// Subject (text/template with a PromoteSummary)
+	Subject string
+	// Template of the body (text/template with a PromoteSummary), default is a text summary
+	Template string
+}
+
+//PromoteSummary is the summary of a promote run used for the e-mail templates
+type PromoteSummary struct {
+	NotificationPayload
+	FromEnvironment string
+	Error           string //Error of the promote run, empty if all deployments could be created
+}
+
+// Validate the e-mail config
+func (config *EmailConfig) Validate() []error {
+	validationErrors := make([]error, 0)
+	if config.Host == "" {
+		return validationErrors
+	}
+	if config.From == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("e-mail From can't be empty if Host is set"))
+	}
+	if len(config.To) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("e-mail To can't be empty if Host is set"))
+	}
+	if _, _, err := config.templates(); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("e-mail template is not valid: %s", err))
+	}
+	return validationErrors
+}
+
+//notifyPromoteSummary sends the summary e-mail of a promote run, if configured
+func notifyPromoteSummary(cli *Cli, commandOptions *CommandOptionsPromoteDeployments, deployments Deployments, promoteErr error) {
+	config := &cli.Client.config.Notification.Email
+	if config.Host == "" {
+		return
+	}
+	summary := PromoteSummary{
+		NotificationPayload: newNotificationPayload(NotificationEventFinished, "promote", commandOptions.Environment, deployments),
+		FromEnvironment:     commandOptions.FromEnvironment,
+	}
+	if promoteErr != nil {
+		summary.Success = false
+		summary.Error = promoteErr.Error()
+	}
+	if err := config.send(&summary); err != nil {
+		log.Printf("Error on sending the summary e-mail: %v", err)
+	}
+}
+
+//templates parses the subject and body templates
+func (config *EmailConfig) templates() (*template.Template, *template.Template, error) {
+	subject, body := config.Subject, config.Template
+	if subject == "" {
+		subject = defaultEmailSubject
+	}
+	if body == "" {
+		body = defaultEmailTemplate
+	}
+	subjectTemplate, err := template.New("subject").Parse(subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	bodyTemplate, err := template.New("body").Parse(body)
+	return subjectTemplate, bodyTemplate, err
+}
+
+//message returns the e-mail with headers and the rendered templates
+func (config *EmailConfig) message(summary *PromoteSummary) ([]byte, error) {
+	subjectTemplate, bodyTemplate, err := config.templates()
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+	var subject, body bytes.Buffer
+	if err := subjectTemplate.Execute(&subject, summary); err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+	if err := bodyTemplate.Execute(&body, summary); err != nil {
+		return nil, fmt.Errorf("invalid template: %v", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(config.To, ", "))
+	//Line breaks of the template values would start new headers
+	fmt.Fprintf(&msg, "Subject: %s\r\n", strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(subject.String()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+//send sends the summary to the recipients
+func (config *EmailConfig) send(summary *PromoteSummary) error {
+	msg, err := config.message(summary)
+	if err != nil {
+		return err
+	}
+	port := config.Port
+	if port == 0 {
+		port = 25
+	}
+
+	netConn, err := net.DialTimeout("tcp", net.JoinHostPort(config.Host, strconv.Itoa(port)), emailTimeout)
+	if err != nil {
+		return err
+	}
+	if err := netConn.SetDeadline(time.Now().Add(emailTimeout)); err != nil {
+		netConn.Close()
+		return err
+	}
+	conn, err := smtp.NewClient(netConn, config.Host)
+	if err != nil {
+		netConn.Close()
+		return err
+	}
+	defer conn.Close()
+	if config.StartTLS {
+		if err := conn.StartTLS(&tls.Config{ServerName: config.Host}); err != nil {
+			return err
+		}
+	}
+	if config.Username != "" {
+		if err := conn.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := conn.Mail(config.From); err != nil {
+		return err
+	}
+	for _, to := range config.To {
+		if err := conn.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	data, err := conn.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(msg); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return conn.Quit()
+}
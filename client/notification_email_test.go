@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+//newSMTPServer starts an SMTP stand-in and returns its port, the received mails are sent to the channel
+func newSMTPServer(t *testing.T, mails chan<- string) (net.Listener, int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Couldn't start SMTP stand-in: %s", err)
+	}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		var mail strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM"), strings.HasPrefix(command, "RCPT TO"):
+				mail.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					mail.WriteString(line)
+				}
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				mails <- mail.String()
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+	return listener, listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestPromoteSendsSummaryEmail(t *testing.T) {
+
+	// given
+	mails := make(chan string, 1)
+	listener, port := newSMTPServer(t, mails)
+	defer listener.Close()
+	config := Config{Notification: NotificationConfig{Email: EmailConfig{Host: "127.0.0.1", Port: port, From: "liima@example.com", To: []string{"release@example.com", "ops@example.com"}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "T", Environment: "P", Wait: true, MaxWaitTime: 60})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	mail := <-mails
+	for _, expected := range []string{
+		"MAIL FROM:<liima@example.com>",
+		"RCPT TO:<release@example.com>",
+		"RCPT TO:<ops@example.com>",
+		"To: release@example.com, ops@example.com\r\n",
+		"Subject: Liima promote T to P: success\r\n",
+		"Promote from environment T to P\r\n1 deployment(s) on environment P: 1 success\r\n\r\n",
+		"Test: success, release \r\n  testapp 1.0\r\n",
+	} {
+		if !strings.Contains(mail, expected) {
+			t.Errorf("Expecting %q in mail, got: %s", expected, mail)
+		}
+	}
+}
+
+func TestEmailTemplate(t *testing.T) {
+
+	// given
+	config := EmailConfig{From: "liima@example.com", To: []string{"ops@example.com"}, Subject: "{{.Environment}}", Template: "{{.Error}} {{len .Deployments}}"}
+	summary := PromoteSummary{NotificationPayload: newNotificationPayload(NotificationEventFinished, "promote", "P", Deployments{}), Error: "failed"}
+
+	// when
+	msg, err := config.message(&summary)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if !strings.Contains(string(msg), "Subject: P\r\n") || !strings.HasSuffix(string(msg), "\r\n\r\nfailed 0") {
+		t.Fatalf("Unexpected message: %s", msg)
+	}
+}
+
+func TestEmailSubjectWithoutLineBreaks(t *testing.T) {
+
+	// given
+	config := EmailConfig{From: "liima@example.com", To: []string{"ops@example.com"}, Subject: "{{.Error}}"}
+	summary := PromoteSummary{NotificationPayload: newNotificationPayload(NotificationEventFinished, "promote", "P", Deployments{}), Error: "failed\r\nBcc: other@example.com\rX: y"}
+
+	// when
+	msg, err := config.message(&summary)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if !strings.Contains(string(msg), "Subject: failed Bcc: other@example.com X: y\r\n") {
+		t.Fatalf("Unexpected message: %s", msg)
+	}
+}
+
+func TestEmailTimeout(t *testing.T) {
+
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	defer listener.Close()
+	//Accepts the connection, but never sends the greeting
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			defer conn.Close()
+			time.Sleep(2 * time.Second)
+		}
+	}()
+	defer func(timeout time.Duration) { emailTimeout = timeout }(emailTimeout)
+	emailTimeout = 100 * time.Millisecond
+	config := EmailConfig{Host: "127.0.0.1", Port: listener.Addr().(*net.TCPAddr).Port, From: "liima@example.com", To: []string{"ops@example.com"}}
+	summary := PromoteSummary{NotificationPayload: newNotificationPayload(NotificationEventFinished, "promote", "P", Deployments{})}
+
+	// when
+	start := time.Now()
+	err = config.send(&summary)
+
+	// then
+	if err == nil || time.Since(start) > time.Second {
+		t.Errorf("Expecting a timeout error, got %v after %v", err, time.Since(start))
+	}
+}
+
+func TestEmailConfigValidate(t *testing.T) {
+
+	// given
+	config := EmailConfig{Host: "localhost", Template: "{{.Error"}
+
+	// when
+	errs := config.Validate()
+
+	// then
+	if len(errs) != 3 {
+		t.Fatalf("Expecting 3 errors, got: %v", errs)
+	}
+}
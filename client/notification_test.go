@@ -0,0 +1,162 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//webhookRequest is a request received by the webhook stand-in
+type webhookRequest struct {
+	signature string
+	body      []byte
+}
+
+//newWebhookServer returns a webhook stand-in answering with the given status codes, then with 200
+func newWebhookServer(requests *[]webhookRequest, statusCodes ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, webhookRequest{signature: r.Header.Get(SignatureHeader), body: body})
+		if len(*requests) <= len(statusCodes) {
+			w.WriteHeader(statusCodes[len(*requests)-1])
+		}
+	}))
+}
+
+func TestPromoteNotifiesWebhook(t *testing.T) {
+
+	// given
+	requests := []webhookRequest{}
+	webhook := newWebhookServer(&requests)
+	defer webhook.Close()
+	config := Config{Notification: NotificationConfig{Webhooks: []WebhookConfig{{URL: webhook.URL, Secret: "secret"}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "T", Environment: "P", Wait: true, MaxWaitTime: 60})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Expecting one webhook request, got %d", len(requests))
+	}
+	assertString(t, Sign("secret", requests[0].body), requests[0].signature, "signature")
+	payload := NotificationPayload{}
+	if err := json.Unmarshal(requests[0].body, &payload); err != nil {
+		t.Fatalf("Excepting JSON payload: %s", err)
+	}
+	assertString(t, NotificationEventFinished, payload.Event, "event")
+	assertString(t, "promote", payload.Command, "command")
+	assertString(t, "1 deployment(s) on environment P: 1 success", payload.Summary, "summary")
+	if !payload.Success || len(payload.Deployments) != 1 {
+		t.Fatalf("Expecting one successful deployment, got %v", payload)
+	}
+}
+
+func TestWebhookTemplateAndRetries(t *testing.T) {
+
+	// given
+	webhookRetryDelay = 0
+	requests := []webhookRequest{}
+	webhook := newWebhookServer(&requests, http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer webhook.Close()
+	retries := 2
+	config := WebhookConfig{URL: webhook.URL, Template: `{"text": {{json .Summary}}}`, Retries: &retries}
+	payload := newNotificationPayload(NotificationEventFinished, "create", "P", Deployments{newTestDeployment(1, 0, DeploymentStateFailed, "app", "1.0")})
+
+	// when
+	err := config.send(&payload)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expecting three webhook requests, got %d", len(requests))
+	}
+	assertString(t, `{"text": "1 deployment(s) on environment P: 1 failed"}`, string(requests[2].body), "body")
+	assertString(t, "", requests[2].signature, "signature")
+}
+
+func TestWebhookNoRetryOnClientError(t *testing.T) {
+
+	// given
+	webhookRetryDelay = 0
+	requests := []webhookRequest{}
+	webhook := newWebhookServer(&requests, http.StatusBadRequest)
+	defer webhook.Close()
+	config := WebhookConfig{URL: webhook.URL}
+	payload := newNotificationPayload(NotificationEventFinished, "create", "P", Deployments{})
+
+	// when
+	err := config.send(&payload)
+
+	// then
+	if err == nil {
+		t.Fatalf("Excepting an error")
+	}
+	if len(requests) != 1 {
+		t.Fatalf("Expecting one webhook request, got %d", len(requests))
+	}
+}
+
+func TestNotifyStateChanges(t *testing.T) {
+
+	// given
+	requests := []webhookRequest{}
+	webhook := newWebhookServer(&requests)
+	defer webhook.Close()
+	config := Config{Notification: NotificationConfig{Webhooks: []WebhookConfig{{URL: webhook.URL, OnStateChange: true}, {URL: webhook.URL}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	states := map[int]DeploymentState{1: DeploymentStateScheduled, 2: DeploymentStateSuccess}
+
+	// when
+	notifyStateChanges(&cli, Deployments{
+		newTestDeployment(1, 0, DeploymentStateSuccess, "app", "1.0"),
+		newTestDeployment(2, 0, DeploymentStateSuccess, "app", "1.0"),
+	}, states)
+
+	// then
+	if len(requests) != 1 {
+		t.Fatalf("Expecting one webhook request, got %d", len(requests))
+	}
+	if states[1] != DeploymentStateSuccess {
+		t.Fatalf("Expecting updated state, got %s", states[1])
+	}
+}
+
+func TestNotifyFinishedWithError(t *testing.T) {
+
+	// given
+	requests := []webhookRequest{}
+	webhook := newWebhookServer(&requests)
+	defer webhook.Close()
+	config := Config{Notification: NotificationConfig{Webhooks: []WebhookConfig{{URL: webhook.URL}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	deployments := Deployments{newTestDeployment(1, 0, DeploymentStateProgress, "testapp", "1.0")}
+
+	// when
+	notifyFinished(&cli, "create", "P", deployments, errors.New("Timeout on deployment"))
+
+	// then
+	if len(requests) != 1 {
+		t.Fatalf("Expecting one webhook request, got %d", len(requests))
+	}
+	payload := NotificationPayload{}
+	if err := json.Unmarshal(requests[0].body, &payload); err != nil {
+		t.Fatalf("Excepting JSON payload: %s", err)
+	}
+	assertString(t, "Timeout on deployment", payload.Error, "error")
+	assertString(t, "1 deployment(s) on environment P: 1 progress, error: Timeout on deployment", payload.Summary, "summary")
+	if payload.Success {
+		t.Errorf("Expecting no success on an error, got %v", payload)
+	}
+}
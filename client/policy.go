@@ -0,0 +1,409 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+	"gopkg.in/yaml.v3"
+)
+
+//defaultAuditLog is the file name of the audit log next to the policy file
+const defaultAuditLog = "policy-audit.log"
+
+//Policy is the deployment policy of the environments, read from the policy file
+type Policy struct {
+	TimeZone     string                       `yaml:"timeZone"` //Timezone of the windows and freezes, local timezone if empty
+	AuditLog     string                       `yaml:"auditLog"` //File the policy overrides are recorded in, relative to the policy file, default policy-audit.log
+	Environments map[string]EnvironmentPolicy `yaml:"environments"`
+}
+
+//EnvironmentPolicy is the deployment policy of an environment
+type EnvironmentPolicy struct {
+	RequireReason bool               `yaml:"requireReason"` //Deployments need a reason
+	Windows       []DeploymentWindow `yaml:"windows"`       //Allowed deployment windows, any time if empty
+	Freezes       []DeploymentFreeze `yaml:"freezes"`       //No deployments during the freezes
+}
+
+//DeploymentWindow is a time window on the given weekdays
+//A window with To before From crosses midnight and ends on the next day: "22:00"-"02:00" on Fri is Fri 22:00 to Sat 02:00
+type DeploymentWindow struct {
+	Days []string `yaml:"days"` //Weekdays "Mon" or "Monday" the window begins, every day if empty
+	From string   `yaml:"from"` //Begin "hh:mm"
+	To   string   `yaml:"to"`   //End "hh:mm" (exclusive), "24:00" for the end of the day
+}
+
+//DeploymentFreeze is a freeze from the first to the last day (inclusive)
+type DeploymentFreeze struct {
+	From   string `yaml:"from"` //First day "YYYY-MM-DD"
+	To     string `yaml:"to"`   //Last day "YYYY-MM-DD"
+	Reason string `yaml:"reason"`
+}
+
+//PolicyOptions used for the policy check of the command options (flags)
+type PolicyOptions struct {
+	Reason         string //Reason of the deployment, recorded on overrides
+	OverridePolicy bool   //Deploy despite policy violations, the override is recorded in the audit log
+	ShiftToWindow  bool   //Shift a deployment date outside the windows or in a freeze to the next allowed time
+}
+
+//PolicyViolationError is the error of a deployment violating the policy of an environment
+type PolicyViolationError struct {
+	Environment string
+	Violations  []string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("Deployment violates the policy of environment %s: %s", e.Environment, strings.Join(e.Violations, ", "))
+}
+
+//policyAuditEntry is a recorded policy override
+type policyAuditEntry struct {
+	Time           string   `json:"time"`
+	User           string   `json:"user"`
+	Command        string   `json:"command"`
+	Environment    string   `json:"environment"`
+	DeploymentDate string   `json:"deploymentDate"`
+	Reason         string   `json:"reason"`
+	Violations     []string `json:"violations"`
+}
+
+//ReadPolicy reads and validates a policy from YAML
+func ReadPolicy(r io.Reader) (*Policy, error) {
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Couldn't read policy: %v", err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("Invalid policy: %v", err)
+	}
+	return policy, nil
+}
+
+//Validate the policy
+func (policy *Policy) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	if _, err := util.LoadLocation(policy.TimeZone); err != nil {
+		errorList = append(errorList, err.Error())
+	}
+	for environment, environmentPolicy := range policy.Environments {
+		for _, window := range environmentPolicy.Windows {
+			for _, day := range window.Days {
+				_, err := parseWeekday(day)
+				util.Check(&errorList, err == nil, "environment %s: invalid weekday %q", environment, day)
+			}
+			from, errFrom := parseClock(window.From)
+			to, errTo := parseClock(window.To)
+			util.Check(&errorList, errFrom == nil && errTo == nil && from != to && from < 24*60, "environment %s: invalid window %s-%s, want hh:mm with from different from to", environment, window.From, window.To)
+		}
+		for _, freeze := range environmentPolicy.Freezes {
+			from, _, errFrom := util.ParseDay(freeze.From, time.UTC)
+			to, _, errTo := util.ParseDay(freeze.To, time.UTC)
+			util.Check(&errorList, errFrom == nil && errTo == nil && !to.Before(from), "environment %s: invalid freeze %s - %s, want YYYY-MM-DD with from not after to", environment, freeze.From, freeze.To)
+		}
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//resolveEnvironments replaces the environments given by name or alias with their names, unknown environments are an error
+func (policy *Policy) resolveEnvironments(cli *Cli) error {
+	environments := map[string]EnvironmentPolicy{}
+	for key, environmentPolicy := range policy.Environments {
+		name, err := ResolveEnvironment(cli, key)
+		if err != nil {
+			return err
+		}
+		if _, ok := environments[name]; ok {
+			return fmt.Errorf("environment %s is defined twice", name)
+		}
+		environments[name] = environmentPolicy
+	}
+	policy.Environments = environments
+	return nil
+}
+
+//loadPolicy returns the policy of the policy file in the config, nil if no policy file is configured
+//The policy is read once per client
+func loadPolicy(cli *Cli) (*Policy, error) {
+	path := cli.Client.config.PolicyFile
+	if path == "" {
+		return nil, nil
+	}
+	if cli.Client.policy != nil {
+		return cli.Client.policy, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read policy: %v", err)
+	}
+	defer file.Close()
+	policy, err := ReadPolicy(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.resolveEnvironments(cli); err != nil {
+		return nil, fmt.Errorf("Invalid policy: %v", err)
+	}
+	if policy.AuditLog == "" {
+		policy.AuditLog = defaultAuditLog
+	}
+	if !filepath.IsAbs(policy.AuditLog) {
+		policy.AuditLog = filepath.Join(filepath.Dir(path), policy.AuditLog)
+	}
+	cli.Client.policy = policy
+	return policy, nil
+}
+
+//checkPolicy checks a deployment at the given time (zero time: now) against the policy of the environment before it is created
+//Returns the deployment time, shifted to the next allowed time with ShiftToWindow
+//Violations are allowed with OverridePolicy and a reason, the override is recorded in the audit log
+func checkPolicy(cli *Cli, command string, environment string, deploymentTime time.Time, options *PolicyOptions) (time.Time, error) {
+	policy, err := loadPolicy(cli)
+	if err != nil {
+		return deploymentTime, err
+	}
+	if policy == nil {
+		return deploymentTime, nil
+	}
+	if _, ok := policy.Environments[environment]; !ok {
+		return deploymentTime, nil
+	}
+
+	at := deploymentTime
+	if at.IsZero() {
+		at = time.Now()
+	}
+	violations := []string{}
+	if policy.Environments[environment].RequireReason && strings.TrimSpace(options.Reason) == "" {
+		violations = append(violations, "a reason is required")
+	}
+	timeViolations := policy.timeViolations(environment, at)
+	if len(timeViolations) > 0 && options.ShiftToWindow {
+		next, err := policy.nextAllowedTime(environment, at)
+		if err != nil {
+			return deploymentTime, err
+		}
+		log.Printf("Deployment date on environment %s shifted to the next allowed time: %s", environment, next.Format(DisplayDateTimeFormat))
+		deploymentTime = next
+		timeViolations = nil
+	}
+	violations = append(violations, timeViolations...)
+
+	if len(violations) == 0 {
+		return deploymentTime, nil
+	}
+	if !options.OverridePolicy {
+		return deploymentTime, &PolicyViolationError{Environment: environment, Violations: violations}
+	}
+	if strings.TrimSpace(options.Reason) == "" {
+		return deploymentTime, fmt.Errorf("want a reason to override the policy of environment %s", environment)
+	}
+
+	//Record the override
+	entry := policyAuditEntry{
+		Time:           time.Now().Format(time.RFC3339),
+		User:           currentUser(),
+		Command:        command,
+		Environment:    environment,
+		DeploymentDate: at.Format(time.RFC3339),
+		Reason:         options.Reason,
+		Violations:     violations,
+	}
+	if err := policy.record(&entry); err != nil {
+		return deploymentTime, fmt.Errorf("Couldn't record the policy override: %v", err)
+	}
+	log.Printf("Policy of environment %s overridden: %s", environment, strings.Join(violations, ", "))
+	return deploymentTime, nil
+}
+
+//checkPolicyOnce checks the policy of a command creating several deployments on an environment and returns the absolute
+//deployment date, empty to deploy immediately. The deployments are created with policyChecked, so an override is recorded once per command
+func checkPolicyOnce(cli *Cli, command string, environment string, date string, timeZone string, options *PolicyOptions) (string, error) {
+	t, err := deploymentTime(date, timeZone, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if t, err = checkPolicy(cli, command, environment, t, options); err != nil {
+		return "", err
+	}
+
+	//Relative dates ("+2h") are resolved once, the same for all deployments
+	if t.IsZero() {
+		return "", nil
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+//timeViolations returns the freezes and the missing window of a deployment time
+func (policy *Policy) timeViolations(environment string, t time.Time) []string {
+	violations := []string{}
+	loc, _ := util.LoadLocation(policy.TimeZone)
+	t = t.In(loc)
+	environmentPolicy := policy.Environments[environment]
+	for _, freeze := range environmentPolicy.Freezes {
+		if _, end := freeze.period(loc); freeze.contains(t, loc) {
+			violation := fmt.Sprintf("freeze until %s", end.Add(-time.Minute).Format(DisplayDateTimeFormat))
+			if freeze.Reason != "" {
+				violation += " (" + freeze.Reason + ")"
+			}
+			violations = append(violations, violation)
+		}
+	}
+	if len(environmentPolicy.Windows) > 0 && !environmentPolicy.inWindow(t) {
+		violations = append(violations, fmt.Sprintf("%s is outside the deployment windows", t.Format(DisplayDateTimeFormat)))
+	}
+	return violations
+}
+
+//nextAllowedTime returns the first time from t on, which is in a window and not in a freeze
+func (policy *Policy) nextAllowedTime(environment string, t time.Time) (time.Time, error) {
+	loc, _ := util.LoadLocation(policy.TimeZone)
+	t = t.In(loc)
+	environmentPolicy := policy.Environments[environment]
+	//Each iteration skips a freeze or jumps to the next window
+	for i := 0; i < 1000; i++ {
+		inFreeze := false
+		for _, freeze := range environmentPolicy.Freezes {
+			if _, end := freeze.period(loc); freeze.contains(t, loc) {
+				t = end
+				inFreeze = true
+			}
+		}
+		if inFreeze {
+			continue
+		}
+		if len(environmentPolicy.Windows) == 0 || environmentPolicy.inWindow(t) {
+			return t, nil
+		}
+		t = environmentPolicy.nextWindowStart(t)
+	}
+	return time.Time{}, fmt.Errorf("no allowed deployment time found on environment %s", environment)
+}
+
+//inWindow returns true if the time is in a window, the end of a window crossing midnight is on the day after its weekday
+func (environmentPolicy *EnvironmentPolicy) inWindow(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	previousDay := (t.Weekday() + 6) % 7
+	for _, window := range environmentPolicy.Windows {
+		from, _ := parseClock(window.From)
+		to, _ := parseClock(window.To)
+		if from < to && window.onDay(t.Weekday()) && minutes >= from && minutes < to {
+			return true
+		}
+		if from > to && ((window.onDay(t.Weekday()) && minutes >= from) || (window.onDay(previousDay) && minutes < to)) {
+			return true
+		}
+	}
+	return false
+}
+
+//nextWindowStart returns the begin of the next window after t
+func (environmentPolicy *EnvironmentPolicy) nextWindowStart(t time.Time) time.Time {
+	next := time.Time{}
+	for day := 0; day <= 7; day++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, 0, 0, t.Location())
+		for _, window := range environmentPolicy.Windows {
+			from, _ := parseClock(window.From)
+			start := time.Date(date.Year(), date.Month(), date.Day(), from/60, from%60, 0, 0, t.Location())
+			if window.onDay(date.Weekday()) && start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
+
+//onDay returns true if the window is on the weekday
+func (window *DeploymentWindow) onDay(weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, day := range window.Days {
+		if d, _ := parseWeekday(day); d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+//period returns the begin and the end (exclusive) of the freeze
+func (freeze *DeploymentFreeze) period(loc *time.Location) (time.Time, time.Time) {
+	begin, _, _ := util.ParseDay(freeze.From, loc)
+	_, end, _ := util.ParseDay(freeze.To, loc)
+	return begin, end
+}
+
+//contains returns true if the time is in the freeze
+func (freeze *DeploymentFreeze) contains(t time.Time, loc *time.Location) bool {
+	begin, end := freeze.period(loc)
+	return !t.Before(begin) && t.Before(end)
+}
+
+//record appends the override to the audit log
+func (policy *Policy) record(entry *policyAuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(policy.AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+//parseWeekday parses a weekday "Mon" or "Monday"
+func parseWeekday(input string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(input, day.String()) || strings.EqualFold(input, day.String()[:3]) {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", input)
+}
+
+//parseClock parses "hh:mm" and returns the minutes of the day, "24:00" is the end of the day
+func parseClock(input string) (int, error) {
+	if input == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want hh:mm", input)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+//currentUser returns the name of the user running liimactl
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+//testPolicy defines the policy of environment P by its alias
+const testPolicy = `
+timeZone: Europe/Zurich
+environments:
+  prod-ch:
+    requireReason: true
+    windows:
+      - days: [Mon, Tue, Wed, Thu]
+        from: "08:00"
+        to: "17:00"
+    freezes:
+      - from: 2026-12-21
+        to: 2027-01-04
+        reason: Christmas
+`
+
+//newPolicyCli returns a mock client with the test policy in a temporary directory
+func newPolicyCli(t *testing.T) (*Cli, string) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(path, []byte(testPolicy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := Config{PolicyFile: path}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	return &cli, dir
+}
+
+func TestReadPolicyInvalid(t *testing.T) {
+
+	// when
+	_, err := ReadPolicy(strings.NewReader(`
+environments:
+  P:
+    windows:
+      - days: [Someday]
+        from: "17:00"
+        to: "17:00"
+    freezes:
+      - from: 2027-01-04
+        to: 2026-12-21
+`))
+
+	// then
+	if err == nil {
+		t.Fatalf("Excepting an error")
+	}
+	for _, expected := range []string{"invalid weekday \"Someday\"", "invalid window 17:00-17:00", "invalid freeze 2027-01-04 - 2026-12-21"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expecting %q in error, got: %s", expected, err)
+		}
+	}
+}
+
+func TestLoadPolicyUnknownEnvironment(t *testing.T) {
+
+	// given
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte("environments:\n  X:\n    requireReason: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&Config{PolicyFile: path})
+
+	// when
+	_, err := loadPolicy(&cli)
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Invalid policy: unknown environment X") {
+		t.Errorf("Expecting error unknown environment X, got %v", err)
+	}
+}
+
+func TestPolicyNextAllowedTime(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Zurich")
+	cli, _ := newPolicyCli(t)
+	policy, err := loadPolicy(cli)
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	var tests = []struct {
+		name     string
+		at       time.Time
+		expected time.Time
+	}{
+		{"in window", time.Date(2026, 10, 19, 10, 0, 0, 0, loc), time.Date(2026, 10, 19, 10, 0, 0, 0, loc)},
+		{"evening", time.Date(2026, 10, 19, 18, 0, 0, 0, loc), time.Date(2026, 10, 20, 8, 0, 0, 0, loc)},
+		{"friday", time.Date(2026, 10, 23, 10, 0, 0, 0, loc), time.Date(2026, 10, 26, 8, 0, 0, 0, loc)},
+		{"freeze", time.Date(2026, 12, 22, 10, 0, 0, 0, loc), time.Date(2027, 1, 5, 8, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			next, err := policy.nextAllowedTime("P", tt.at)
+
+			// then
+			if err != nil {
+				t.Fatalf("Excepting no error: %s", err)
+			}
+			if !next.Equal(tt.expected) {
+				t.Errorf("Expecting %s, got %s", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestPolicyWindowOverMidnight(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Zurich")
+	policy := &Policy{TimeZone: "Europe/Zurich", Environments: map[string]EnvironmentPolicy{
+		"P": {Windows: []DeploymentWindow{{Days: []string{"Fri"}, From: "22:00", To: "02:00"}}},
+	}}
+	if err := policy.validate(); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	var tests = []struct {
+		name     string
+		at       time.Time
+		expected time.Time
+	}{
+		{"friday night", time.Date(2026, 10, 23, 23, 0, 0, 0, loc), time.Date(2026, 10, 23, 23, 0, 0, 0, loc)},
+		{"saturday morning", time.Date(2026, 10, 24, 1, 30, 0, 0, loc), time.Date(2026, 10, 24, 1, 30, 0, 0, loc)},
+		{"after the window", time.Date(2026, 10, 24, 2, 0, 0, 0, loc), time.Date(2026, 10, 30, 22, 0, 0, 0, loc)},
+		{"friday morning", time.Date(2026, 10, 23, 1, 0, 0, 0, loc), time.Date(2026, 10, 23, 22, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			next, err := policy.nextAllowedTime("P", tt.at)
+
+			// then
+			if err != nil {
+				t.Fatalf("Excepting no error: %s", err)
+			}
+			if !next.Equal(tt.expected) {
+				t.Errorf("Expecting %s, got %s", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Zurich")
+	friday := time.Date(2026, 10, 23, 18, 0, 0, 0, loc)
+
+	var tests = []struct {
+		name        string
+		environment string
+		options     PolicyOptions
+		expected    time.Time
+		expectedErr string
+		recorded    bool
+	}{
+		{"no policy", "T", PolicyOptions{}, friday, "", false},
+		{"violations", "P", PolicyOptions{}, friday, "Deployment violates the policy of environment P: a reason is required, 2026-10-23 18:00 CEST is outside the deployment windows", false},
+		{"shift", "P", PolicyOptions{Reason: "release", ShiftToWindow: true}, time.Date(2026, 10, 26, 8, 0, 0, 0, loc), "", false},
+		{"override without reason", "P", PolicyOptions{OverridePolicy: true}, friday, "want a reason to override the policy of environment P", false},
+		{"override", "P", PolicyOptions{Reason: "hotfix", OverridePolicy: true}, friday, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// given
+			cli, dir := newPolicyCli(t)
+
+			// when
+			deploymentTime, err := checkPolicy(cli, "create Test", tt.environment, friday, &tt.options)
+
+			// then
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("Excepting no error: %s", err)
+			}
+			if tt.expectedErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.expectedErr)) {
+				t.Fatalf("Expecting error %q, got: %v", tt.expectedErr, err)
+			}
+			if !deploymentTime.Equal(tt.expected) {
+				t.Errorf("Expecting %s, got %s", tt.expected, deploymentTime)
+			}
+			audit, _ := os.ReadFile(filepath.Join(dir, defaultAuditLog))
+			if tt.recorded != strings.Contains(string(audit), `"reason":"hotfix"`) {
+				t.Errorf("Expecting recorded %t, got audit log: %s", tt.recorded, audit)
+			}
+		})
+	}
+}
+
+func TestCreateDeploymentPolicyViolation(t *testing.T) {
+
+	// given
+	cli, _ := newPolicyCli(t)
+	commandOptions := CommandOptionsCreateDeployment{AppServer: "Test", Environment: "P", AppName: []string{"app"}, AppVersion: []string{"1.0"}, DeploymentDate: "2026-12-24 10:00", TimeZone: "Europe/Zurich"}
+
+	// when
+	_, err := CreateDeployment(cli, &commandOptions)
+
+	// then
+	var violation *PolicyViolationError
+	if !errors.As(err, &violation) {
+		t.Fatalf("Expecting policy violation, got: %v", err)
+	}
+	assertString(t, "freeze until 2027-01-04 23:59 CET (Christmas)", violation.Violations[1], "violation")
+}
+
+func TestRollbackDeploymentsPolicyOverride(t *testing.T) {
+
+	// given
+	cli, dir := newPolicyCli(t)
+	plans := []RollbackPlan{
+		{AppServerName: "Test", Target: newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0")},
+		{AppServerName: "Other", Target: newTestDeployment(2, 1000, DeploymentStateSuccess, "app2", "2.0")},
+	}
+	commandOptions := CommandOptionsRollbackDeployments{AppServer: []string{"Test", "Other"}, Environment: "P", DeploymentDate: "2026-12-24 10:00", TimeZone: "Europe/Zurich"}
+
+	// when
+	_, err := RollbackDeployments(cli, &commandOptions, plans)
+
+	// then
+	var violation *PolicyViolationError
+	if !errors.As(err, &violation) {
+		t.Fatalf("Expecting policy violation, got: %v", err)
+	}
+
+	// when
+	commandOptions.PolicyOptions = PolicyOptions{Reason: "hotfix", OverridePolicy: true}
+	deployments, err := RollbackDeployments(cli, &commandOptions, plans)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 2 {
+		t.Errorf("Expecting two deployments, got %v", deployments)
+	}
+	//The override is checked and recorded once for the rollback
+	audit, _ := os.ReadFile(filepath.Join(dir, defaultAuditLog))
+	if got := strings.Count(string(audit), `"reason":"hotfix"`); got != 1 {
+		t.Errorf("Expecting one recorded override, got audit log: %s", audit)
+	}
+}
@@ -13,26 +13,45 @@ import (
 
 //CommandOptionsPromoteDeployments used for the command options (flags)
 type CommandOptionsPromoteDeployments struct {
-	Environment          string   `json:"environmentName"`
-	DeploymentDate       string   `json:"deploymentDate"`
-	ExecuteShakedownTest bool     `json:"executeShakedownTest"`
-	Wait                 bool     //Wait as long the WaitTime until the deployment success or failed
-	MaxWaitTime          int      //Max wait time [seconds] until the deployment success or failed
-	FromEnvironment      string   //Deploy last deployment from given environment
-	WhitelistAppServer   []string //Whitelist with all appServer, which should be deployed, if not WhitelistAppServer is defined, the whole environment will deployed (exclusive blacklist)
-	BlacklistAppServer   []string //Blacklist with all appServer, which should not be deployed
-	BlacklistRuntime     []string //Blacklist with all runtimes, which should not be deployed
-	Silent               bool     //silent mode, no confirmation of promote the whole environment
+	Environment             string   `json:"environmentName"`
+	DeploymentDate          string   `json:"deploymentDate"`
+	TimeZone                string   //Timezone of the DeploymentDate, local timezone if empty
+	ExecuteShakedownTest    bool     `json:"executeShakedownTest"`
+	Wait                    bool     //Wait as long the WaitTime until the deployment success or failed
+	MaxWaitTime             int      //Max wait time [seconds] until the deployment success or failed
+	FromEnvironment         string   //Deploy last deployment from given environment
+	WhitelistAppServer      []string //Patterns (glob "aps_*", regex "re:^kube_.*" or filter file "@file") of all lists: Whitelist with all appServer, which should be deployed, if not WhitelistAppServer is defined, the whole environment will deployed (exclusive blacklist)
+	BlacklistAppServer      []string //Blacklist with all appServer, which should not be deployed
+	BlacklistRuntime        []string //Blacklist with all runtimes, which should not be deployed
+	WhitelistRuntime        []string //Whitelist with all runtimes, which should be deployed, all if empty
+	WhitelistApplication    []string //Only app servers with at least one of these applications are deployed, all if empty
+	BlacklistApplication    []string //App servers with one of these applications are not deployed
+	Silent                  bool     //silent mode, no confirmation of promote the whole environment
+	MinDeploymentAge        string   //Only promote source deployments older than the minimum age, example "12h" or "2d"
+	RequireShakedownTestRun bool     //Only promote source deployments run with a shakedown test, the deployments have no result of the test
+	PolicyOptions
 }
 
 //Validate the given command options
-func (commandOption *CommandOptionsPromoteDeployments) validate() error {
+func (commandOption *CommandOptionsPromoteDeployments) validate(cli *Cli) error {
 
 	//Errorlist
 	var errorList []string
 	//Checks and add to errorList if an error
-	util.Check(&errorList, util.ValidateSingleChar(commandOption.Environment), "want environment with one char, got %s", commandOption.Environment)
-	util.Check(&errorList, util.ValidateSingleChar(commandOption.FromEnvironment), "want FromEnvironment with one char, got %s", commandOption.FromEnvironment)
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	checkEnvironment(cli, &errorList, &commandOption.FromEnvironment, "FromEnvironment")
+	if _, err := deploymentTime(commandOption.DeploymentDate, commandOption.TimeZone, time.Now()); err != nil {
+		errorList = append(errorList, err.Error())
+	}
+	for _, list := range commandOption.patternLists() {
+		for _, err := range util.ValidatePatterns(*list.patterns) {
+			errorList = append(errorList, fmt.Sprintf("%s: %v", list.name, err))
+		}
+	}
+	if commandOption.MinDeploymentAge != "" {
+		_, err := util.ParseDuration(commandOption.MinDeploymentAge)
+		util.Check(&errorList, err == nil, "want MinDeploymentAge as duration like '12h' or '2d', got %s", commandOption.MinDeploymentAge)
+	}
 
 	//Return all errors as one
 	if len(errorList) > 0 {
@@ -42,9 +61,12 @@ func (commandOption *CommandOptionsPromoteDeployments) validate() error {
 }
 
 //checkDeploymentResults waits for deployments to finish or maxWaitTime is reached
-func checkDeploymentResults(cli *Cli, commandOptionsGet *CommandOptionsGetDeployment, maxWaitTime int) (Deployments, error) {
+//The states are the last known states by deployment id, used to notify about state changes
+//The durations of the finished deployments are measured from their creation time by deployment id
+func checkDeploymentResults(cli *Cli, commandOptionsGet *CommandOptionsGetDeployment, maxWaitTime int, states map[int]DeploymentState, created map[int]time.Time) (Deployments, error) {
 
 	checkedDeployments := Deployments{}
+	durations := map[int]time.Duration{}
 
 	const sleepTime = 60 //seconds, polling each x seconds
 	//Timeout 10min = 600sec / 60sec = 10 counts
@@ -61,12 +83,20 @@ func checkDeploymentResults(cli *Cli, commandOptionsGet *CommandOptionsGetDeploy
 		}
 
 		checkedDeployments = deployments
+		notifyStateChanges(cli, deployments, states)
 
-		// Check if all deployments are finished
+		// Check if all deployments are finished, the duration is taken when the final state is seen first
 		allfinished := true
-		for _, actDeployment := range deployments {
+		for i := range deployments {
+			actDeployment := &deployments[i]
 			log.Printf("AppServer: %-30s State: %-20s\n", actDeployment.AppServerName, actDeployment.State)
-			allfinished = allfinished && (actDeployment.State == DeploymentStateFailed || actDeployment.State == DeploymentStateSuccess)
+			finished := actDeployment.State == DeploymentStateFailed || actDeployment.State == DeploymentStateSuccess
+			if _, found := durations[actDeployment.ID]; finished && !found && !created[actDeployment.ID].IsZero() {
+				durations[actDeployment.ID] = time.Since(created[actDeployment.ID])
+			}
+			actDeployment.created = created[actDeployment.ID]
+			actDeployment.Duration = durations[actDeployment.ID]
+			allfinished = allfinished && finished
 		}
 		// Break loop if finished
 		if allfinished {
@@ -84,98 +114,151 @@ func checkDeploymentResults(cli *Cli, commandOptionsGet *CommandOptionsGetDeploy
 }
 
 //PromoteDeployments creates multiple deployments and returns the deploymentresponse
+//A summary e-mail of the run is sent, if configured
 func PromoteDeployments(cli *Cli, commandOptions *CommandOptionsPromoteDeployments) (Deployments, error) {
 	//validate commandoptions
-	if err := commandOptions.validate(); err != nil {
+	if err := commandOptions.expandFilterFiles(); err != nil {
+		return nil, err
+	}
+	if err := commandOptions.validate(cli); err != nil {
 		log.Println("Error command validation: ", err)
 		return nil, err
 	}
 
-	//Create the filter for searching all deployment from an environment
-	commandOptionsGetFilter := CommandOptionsGetDeployment{}
-	commandOptionsGetFilter.Environment = []string{commandOptions.FromEnvironment}
-	commandOptionsGetFilter.OnlyLatest = true
-	commandOptionsGetFilter.DeploymentState = []DeploymentState{DeploymentStateSuccess}
-	commandOptionsGetFilter.TrackingID = -1
-	commandOptionsGetFilter.AppServer = commandOptions.WhitelistAppServer
-
-	//Get all last deployments of the given environment
-	deployments, err := GetDeployment(cli, &commandOptionsGetFilter)
+	//Check the policy once before creating deployments
+	deploymentDate, err := checkPolicyOnce(cli, "promote "+commandOptions.FromEnvironment+" to "+commandOptions.Environment, commandOptions.Environment, commandOptions.DeploymentDate, commandOptions.TimeZone, &commandOptions.PolicyOptions)
 	if err != nil {
-		log.Println("Error on getting the filtered deployments: ", err)
-		return deployments, err
+		return nil, err
 	}
 
-	if len(deployments) == 0 {
-		return nil, fmt.Errorf("There was an error on creating the deployment, no deployment found from environment: %s ", commandOptions.FromEnvironment)
+	deployments, err := promoteDeployments(cli, commandOptions, deploymentDate)
+	notifyPromoteSummary(cli, commandOptions, deployments, err)
+	return deployments, err
+}
+
+//promoteDeployments creates the deployments of the validated command options with the absolute deployment date
+func promoteDeployments(cli *Cli, commandOptions *CommandOptionsPromoteDeployments, deploymentDate string) (Deployments, error) {
+
+	//Select the deployments of the given environment
+	selection, err := selectDeployments(cli, commandOptions)
+	if err != nil {
+		return nil, err
 	}
+	deployments := selection.selected
+	excludedDeployments := selection.excluded
 
-	//Remove all deployments
-	// - defined in the blacklist
-	// - with a runtime on the blacklistRuntime
-	// - without state "success"
-	for i := len(deployments) - 1; i >= 0; i-- {
-		if (util.Contains(deployments[i].AppServerName, commandOptions.BlacklistAppServer)) || (deployments[i].State != DeploymentStateSuccess) || util.Contains(deployments[i].RuntimeName, commandOptions.BlacklistRuntime) {
-			deployments = append(deployments[:i], deployments[i+1:]...)
+	//Check the promotion path before creating deployments, app servers not reaching the soak time are excluded like the promotion gate
+	violations, err := checkPromotionPath(cli, commandOptions.FromEnvironment, commandOptions.Environment, deployments)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		soaked := Deployments{}
+		for i := range deployments {
+			if violation, found := violations[deployments[i].AppServerName]; found {
+				log.Printf("Excluded app server %s: %s", deployments[i].AppServerName, violation)
+				excludedDeployments = append(excludedDeployments, excludedDeployment(&deployments[i], commandOptions.Environment, "soak time of the promotion path not reached, "+violation))
+				continue
+			}
+			soaked = append(soaked, deployments[i])
 		}
+		deployments = soaked
 	}
 
 	//Create deployments
 	createdDeployments := Deployments{}
 
+	//Notify when the deployments are finished or the run stopped with an error after creating deployments
+	notify := func(deployments Deployments, err error) {
+		if commandOptions.Wait || err != nil {
+			notifyFinished(cli, "promote", commandOptions.Environment, deployments, err)
+		}
+	}
+
 	for _, actDeployment := range deployments {
 
-		commandOptionsCreateDeployment := CommandOptionsCreateDeployment{}
-		commandOptionsCreateDeployment.AppServer = actDeployment.AppServerName
-		commandOptionsCreateDeployment.Release = actDeployment.ReleaseName
-		commandOptionsCreateDeployment.Environment = commandOptions.Environment
-		commandOptionsCreateDeployment.DeploymentDate = commandOptions.DeploymentDate
-		commandOptionsCreateDeployment.AppName = make([]string, len(actDeployment.AppsWithVersion))
-		commandOptionsCreateDeployment.AppVersion = make([]string, len(actDeployment.AppsWithVersion))
-		for i := range actDeployment.AppsWithVersion {
-			commandOptionsCreateDeployment.AppName[i] = actDeployment.AppsWithVersion[i].ApplicationName
-			commandOptionsCreateDeployment.AppVersion[i] = actDeployment.AppsWithVersion[i].Version
-		}
+		commandOptionsCreateDeployment := createOptionsFromDeployment(&actDeployment, commandOptions.Environment)
+		commandOptionsCreateDeployment.DeploymentDate = deploymentDate
+		commandOptionsCreateDeployment.policyChecked = true
 
 		deployment, err := CreateDeployment(cli, &commandOptionsCreateDeployment)
 		if err != nil {
 			log.Printf("Error Create Deployment for app server: %s error: %s", actDeployment.AppServerName, err)
-			return createdDeployments, err
+			err = &CreateDeploymentError{AppServerName: actDeployment.AppServerName, Err: err}
+			if len(createdDeployments) > 0 {
+				notify(createdDeployments, err)
+			}
+			return append(createdDeployments, excludedDeployments...), err
 		}
 		createdDeployments = append(createdDeployments, *deployment)
 	}
 
 	//Wait on deployment success or failed
-	if commandOptions.Wait {
-
-		//List with node active=false in liima appserver configuration
-		nodeNotActiveList := Deployments{}
-
-		//Create filter for created deployments
-		commandOptionsGetFilter.Environment = []string{commandOptions.Environment}
-		commandOptionsGetFilter.AppServer = nil
-		commandOptionsGetFilter.DeploymentState = nil
-		commandOptionsGetFilter.OnlyLatest = false
-		for _, actDeployment := range createdDeployments {
-			if actDeployment.ID == -1 {
-				nodeNotActiveList = append(nodeNotActiveList, actDeployment)
-			}
-			commandOptionsGetFilter.ID = append(commandOptionsGetFilter.ID, actDeployment.ID)
-		}
-
-		//Check deployments
-		deployments, err := checkDeploymentResults(cli, &commandOptionsGetFilter, commandOptions.MaxWaitTime)
+	if commandOptions.Wait && len(createdDeployments) > 0 {
+		deployments, err := waitForDeployments(cli, commandOptions.Environment, createdDeployments, commandOptions.MaxWaitTime)
 		if err != nil {
-			return deployments, err
+			//The states are unknown if the deployments couldn't be read
+			if deployments == nil {
+				deployments = createdDeployments
+			}
+			notify(append(deployments, excludedDeployments...), err)
+			return append(deployments, excludedDeployments...), err
 		}
 		createdDeployments = deployments
-
-		//Add not active node to responselist
-		for _, nodeNotActive := range nodeNotActiveList {
-			createdDeployments = append(createdDeployments, nodeNotActive)
-		}
 	}
+	createdDeployments = append(createdDeployments, excludedDeployments...)
+	notify(createdDeployments, nil)
 
 	//Return response
 	return createdDeployments, nil
 }
+
+//createOptionsFromDeployment returns the command options to deploy the release and versions of a deployment on the given environment
+func createOptionsFromDeployment(deployment *DeploymentResponse, environment string) CommandOptionsCreateDeployment {
+	commandOptionsCreateDeployment := CommandOptionsCreateDeployment{}
+	commandOptionsCreateDeployment.AppServer = deployment.AppServerName
+	commandOptionsCreateDeployment.Release = deployment.ReleaseName
+	commandOptionsCreateDeployment.Environment = environment
+	commandOptionsCreateDeployment.AppName = make([]string, len(deployment.AppsWithVersion))
+	commandOptionsCreateDeployment.AppVersion = make([]string, len(deployment.AppsWithVersion))
+	for i := range deployment.AppsWithVersion {
+		commandOptionsCreateDeployment.AppName[i] = deployment.AppsWithVersion[i].ApplicationName
+		commandOptionsCreateDeployment.AppVersion[i] = deployment.AppsWithVersion[i].Version
+	}
+	return commandOptionsCreateDeployment
+}
+
+//waitForDeployments waits until the created deployments on an environment are finished or maxWaitTime is reached
+//Rejected deployments (node active=false) are not checked and returned unchanged
+func waitForDeployments(cli *Cli, environment string, createdDeployments Deployments, maxWaitTime int) (Deployments, error) {
+
+	//List with node active=false in liima appserver configuration
+	nodeNotActiveList := Deployments{}
+
+	//Create filter for created deployments
+	commandOptionsGetFilter := CommandOptionsGetDeployment{}
+	commandOptionsGetFilter.Environment = []string{environment}
+	commandOptionsGetFilter.TrackingID = -1
+	states := map[int]DeploymentState{}
+	created := map[int]time.Time{}
+	for _, actDeployment := range createdDeployments {
+		if actDeployment.ID == -1 {
+			nodeNotActiveList = append(nodeNotActiveList, actDeployment)
+		}
+		commandOptionsGetFilter.ID = append(commandOptionsGetFilter.ID, actDeployment.ID)
+		states[actDeployment.ID] = actDeployment.State
+		created[actDeployment.ID] = actDeployment.created
+	}
+
+	//Check deployments
+	deployments, err := checkDeploymentResults(cli, &commandOptionsGetFilter, maxWaitTime, states, created)
+	if err != nil {
+		return deployments, err
+	}
+
+	//Add not active node to responselist
+	for _, nodeNotActive := range nodeNotActiveList {
+		deployments = append(deployments, nodeNotActive)
+	}
+	return deployments, nil
+}
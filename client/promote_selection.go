@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//SelectionDecision explains why the deployment of an app server is promoted or not
+type SelectionDecision struct {
+	AppServerName string `json:"appServerName"`
+	RuntimeName   string `json:"runtimeName"`
+	Included      bool   `json:"included"`
+	Reason        string `json:"reason"`
+}
+
+//promoteSelection is the selection of the source deployments to promote
+type promoteSelection struct {
+	selected  Deployments         //deployments to promote
+	excluded  Deployments         //deployments failing the promotion gate, reported with state excluded
+	decisions []SelectionDecision //decision of each source deployment
+}
+
+//patternList is a pattern list of the selection with its flag name
+type patternList struct {
+	name     string
+	patterns *[]string
+}
+
+//patternLists returns the pattern lists of the selection
+func (commandOption *CommandOptionsPromoteDeployments) patternLists() []patternList {
+	return []patternList{
+		{"whitelistAppServer", &commandOption.WhitelistAppServer},
+		{"blacklistAppServer", &commandOption.BlacklistAppServer},
+		{"whitelistRuntime", &commandOption.WhitelistRuntime},
+		{"blacklistRuntime", &commandOption.BlacklistRuntime},
+		{"whitelistApplication", &commandOption.WhitelistApplication},
+		{"blacklistApplication", &commandOption.BlacklistApplication},
+	}
+}
+
+//expandFilterFiles replaces the filter files ("@file") of the pattern lists by their patterns
+func (commandOption *CommandOptionsPromoteDeployments) expandFilterFiles() error {
+	for _, list := range commandOption.patternLists() {
+		expanded, err := util.ExpandPatternFiles(*list.patterns)
+		if err != nil {
+			return err
+		}
+		*list.patterns = expanded
+	}
+	return nil
+}
+
+//ExplainPromoteSelection returns for each latest successful deployment of the FromEnvironment, why it would be promoted or not
+func ExplainPromoteSelection(cli *Cli, commandOptions *CommandOptionsPromoteDeployments) ([]SelectionDecision, error) {
+	if err := commandOptions.expandFilterFiles(); err != nil {
+		return nil, err
+	}
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+	selection, err := selectDeployments(cli, commandOptions)
+	if err != nil {
+		return nil, err
+	}
+	return selection.decisions, nil
+}
+
+//selectDeployments returns the latest successful deployments of the FromEnvironment selected by the white- and blacklists and the promotion gate
+func selectDeployments(cli *Cli, commandOptions *CommandOptionsPromoteDeployments) (*promoteSelection, error) {
+
+	//Filter literal app server names on the server, patterns are matched on the client
+	var appServers []string
+	for _, pattern := range commandOptions.WhitelistAppServer {
+		if !util.IsLiteralPattern(pattern) {
+			appServers = nil
+			break
+		}
+		appServers = append(appServers, pattern)
+	}
+
+	//Get all last deployments of the given environment
+	deployments, err := getLatestSuccessfulDeployments(cli, commandOptions.FromEnvironment, appServers)
+	if err != nil {
+		log.Println("Error on getting the filtered deployments: ", err)
+		return nil, err
+	}
+
+	if len(deployments) == 0 {
+		return nil, fmt.Errorf("There was an error on creating the deployment, no deployment found from environment: %s ", commandOptions.FromEnvironment)
+	}
+
+	selection := &promoteSelection{selected: Deployments{}, excluded: Deployments{}, decisions: []SelectionDecision{}}
+	minAge, _ := util.ParseDuration(commandOptions.MinDeploymentAge)
+	now := time.Now()
+	for i := range deployments {
+		decision := commandOptions.selectDeployment(&deployments[i])
+
+		//Exclude deployments failing the promotion gate, they are reported with state excluded
+		if decision.Included {
+			if violation := promotionGateViolation(&deployments[i], commandOptions.FromEnvironment, minAge, commandOptions.RequireShakedownTestRun, now); violation != "" {
+				log.Printf("Excluded app server %s: %s", deployments[i].AppServerName, violation)
+				selection.excluded = append(selection.excluded, excludedDeployment(&deployments[i], commandOptions.Environment, violation))
+				decision.Included = false
+				decision.Reason = "promotion gate: " + violation
+			}
+		}
+
+		if decision.Included {
+			selection.selected = append(selection.selected, deployments[i])
+		}
+		selection.decisions = append(selection.decisions, decision)
+	}
+	return selection, nil
+}
+
+//selectDeployment decides with the white- and blacklists, if the deployment is promoted
+//The app server, runtime and application whitelists have to match (if not empty) and no blacklist may match
+func (commandOption *CommandOptionsPromoteDeployments) selectDeployment(deployment *DeploymentResponse) SelectionDecision {
+	decision := SelectionDecision{AppServerName: deployment.AppServerName, RuntimeName: deployment.RuntimeName}
+	exclude := func(reason string, args ...interface{}) SelectionDecision {
+		decision.Reason = fmt.Sprintf(reason, args...)
+		return decision
+	}
+
+	if deployment.State != DeploymentStateSuccess {
+		return exclude("state %s", deployment.State)
+	}
+	whitelistedBy := ""
+	if len(commandOption.WhitelistAppServer) > 0 {
+		pattern, found := util.FindPattern(deployment.AppServerName, commandOption.WhitelistAppServer)
+		if !found {
+			return exclude("not in whitelistAppServer")
+		}
+		whitelistedBy = fmt.Sprintf(", whitelistAppServer %q", pattern)
+	}
+	if pattern, found := util.FindPattern(deployment.AppServerName, commandOption.BlacklistAppServer); found {
+		return exclude("blacklistAppServer %q", pattern)
+	}
+	if len(commandOption.WhitelistRuntime) > 0 {
+		if _, found := util.FindPattern(deployment.RuntimeName, commandOption.WhitelistRuntime); !found {
+			return exclude("runtime %s not in whitelistRuntime", deployment.RuntimeName)
+		}
+	}
+	if pattern, found := util.FindPattern(deployment.RuntimeName, commandOption.BlacklistRuntime); found {
+		return exclude("runtime %s in blacklistRuntime %q", deployment.RuntimeName, pattern)
+	}
+	if len(commandOption.WhitelistApplication) > 0 {
+		whitelisted := false
+		for _, app := range deployment.AppsWithVersion {
+			_, found := util.FindPattern(app.ApplicationName, commandOption.WhitelistApplication)
+			whitelisted = whitelisted || found
+		}
+		if !whitelisted {
+			return exclude("no application in whitelistApplication")
+		}
+	}
+	for _, app := range deployment.AppsWithVersion {
+		if pattern, found := util.FindPattern(app.ApplicationName, commandOption.BlacklistApplication); found {
+			return exclude("application %s in blacklistApplication %q", app.ApplicationName, pattern)
+		}
+	}
+
+	decision.Included = true
+	decision.Reason = "included" + whitelistedBy
+	return decision
+}
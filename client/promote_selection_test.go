@@ -0,0 +1,78 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestSelectDeployment(t *testing.T) {
+	deployment := newTestDeployment(1, 0, DeploymentStateSuccess, "ch_mobi_bau", "1.0", "ch_mobi_common", "2.0")
+	deployment.AppServerName = "aps_bau"
+	deployment.RuntimeName = "kube_helm"
+
+	var tests = []struct {
+		name             string
+		commandOptions   CommandOptionsPromoteDeployments
+		expectedIncluded bool
+		expectedReason   string
+	}{
+		{"no lists", CommandOptionsPromoteDeployments{}, true, "included"},
+		{"whitelist glob", CommandOptionsPromoteDeployments{WhitelistAppServer: []string{"vvn", "aps_*"}}, true, `included, whitelistAppServer "aps_*"`},
+		{"not whitelisted", CommandOptionsPromoteDeployments{WhitelistAppServer: []string{"vvn"}}, false, "not in whitelistAppServer"},
+		{"blacklist regex", CommandOptionsPromoteDeployments{BlacklistAppServer: []string{"re:_bau$"}}, false, `blacklistAppServer "re:_bau$"`},
+		{"runtime whitelist", CommandOptionsPromoteDeployments{WhitelistRuntime: []string{"EAP*"}}, false, "runtime kube_helm not in whitelistRuntime"},
+		{"runtime blacklist", CommandOptionsPromoteDeployments{BlacklistRuntime: []string{"re:^kube_.*"}}, false, `runtime kube_helm in blacklistRuntime "re:^kube_.*"`},
+		{"application whitelist", CommandOptionsPromoteDeployments{WhitelistApplication: []string{"*_common"}}, true, "included"},
+		{"application not whitelisted", CommandOptionsPromoteDeployments{WhitelistApplication: []string{"ch_mobi_vvn"}}, false, "no application in whitelistApplication"},
+		{"application blacklist", CommandOptionsPromoteDeployments{BlacklistApplication: []string{"*_common"}}, false, `application ch_mobi_common in blacklistApplication "*_common"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			decision := tt.commandOptions.selectDeployment(&deployment)
+
+			// then
+			if decision.Included != tt.expectedIncluded {
+				t.Errorf("Expecting included %t, got %t", tt.expectedIncluded, decision.Included)
+			}
+			assertString(t, tt.expectedReason, decision.Reason, "reason")
+		})
+	}
+}
+
+func TestPromoteDeploymentsInvalidPattern(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "B", Environment: "P", BlacklistRuntime: []string{"re:["}})
+
+	// then
+	if err == nil {
+		t.Fatalf("Excepting an error")
+	}
+	assertString(t, "blacklistRuntime: invalid pattern \"re:[\": error parsing regexp: missing closing ]: `[`", err.Error(), "error")
+}
+
+func TestPromoteDeploymentsWaitDuration(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	deployments, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "T", Environment: "P", Wait: true, MaxWaitTime: 60})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 1 || deployments[0].Duration <= 0 {
+		t.Errorf("Expecting the duration of the finished deployment, got %v", deployments)
+	}
+}
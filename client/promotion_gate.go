@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+//promotionGateViolation returns why a deployment of the source environment may not be promoted, empty if it passes the gate
+//The deployment has to be older than the minimum age (no check if 0) and run with a shakedown test if required
+//Only the request of the shakedown test is checked, the deployments of Liima have no result of the test
+func promotionGateViolation(deployment *DeploymentResponse, sourceEnvironment string, minAge time.Duration, requireShakedownTestRun bool, now time.Time) string {
+	if age := now.Sub(deployment.DeploymentTime()); minAge > 0 && age < minAge {
+		return fmt.Sprintf("deployed on environment %s since %s, less than %s", sourceEnvironment, age.Round(time.Minute), minAge)
+	}
+	if requireShakedownTestRun && !deployment.ExecuteShakedownTest {
+		return fmt.Sprintf("deployed on environment %s without shakedown test", sourceEnvironment)
+	}
+	return ""
+}
+
+//excludedDeployment returns a deployment with state excluded, used to report source deployments not promoted to the environment
+func excludedDeployment(deployment *DeploymentResponse, environment string, reason string) DeploymentResponse {
+	excluded := *deployment
+	excluded.ID = -1
+	excluded.TrackingID = 0
+	excluded.DeploymentDate = 0
+	excluded.EnvironmentName = environment
+	excluded.State = DeploymentStateExcluded
+	excluded.StatusMessage = reason
+	return excluded
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromotionGateViolation(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	deployment := newTestDeployment(1, now.Add(-6*time.Hour).UnixNano()/int64(time.Millisecond), DeploymentStateSuccess, "app", "1.0")
+	withShakedownTest := deployment
+	withShakedownTest.ExecuteShakedownTest = true
+
+	var tests = []struct {
+		name                    string
+		deployment              DeploymentResponse
+		minAge                  time.Duration
+		requireShakedownTestRun bool
+		expected                string
+	}{
+		{"no gate", deployment, 0, false, ""},
+		{"old enough", deployment, 4 * time.Hour, false, ""},
+		{"too young", deployment, 12 * time.Hour, false, "deployed on environment B since 6h0m0s, less than 12h0m0s"},
+		{"without shakedown test", deployment, 0, true, "deployed on environment B without shakedown test"},
+		{"with shakedown test", withShakedownTest, 4 * time.Hour, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			violation := promotionGateViolation(&tt.deployment, "B", tt.minAge, tt.requireShakedownTestRun, now)
+
+			// then
+			assertString(t, tt.expected, violation, "violation")
+		})
+	}
+}
+
+func TestPromoteDeploymentsExcludesGateFailures(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	deployments, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "B", Environment: "P", MinDeploymentAge: "2d", RequireShakedownTestRun: true})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 1 {
+		t.Fatalf("Expecting one deployment, got %v", deployments)
+	}
+	assertString(t, string(DeploymentStateExcluded), string(deployments[0].State), "state")
+	assertString(t, "P", deployments[0].EnvironmentName, "environment")
+	assertString(t, "deployed on environment B without shakedown test", deployments[0].StatusMessage, "status message")
+}
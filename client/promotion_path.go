@@ -0,0 +1,163 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+// PromotionConfig contains the allowed promotions between environments
+type PromotionConfig struct {
+	// Paths of environments in promotion order, example [D, T, I, B, P]
+	// Promotions are only allowed to the next environment of a path, no paths allow all promotions
+	Paths [][]string
+	// Minimum time the promoted versions have been successful on every intermediate environment of the path, example "24h" or "2d" (optional)
+	SoakTime string
+}
+
+// Validate the promotion config
+func (config *PromotionConfig) Validate() []error {
+	validationErrors := make([]error, 0)
+	for _, path := range config.Paths {
+		if len(path) < 2 {
+			validationErrors = append(validationErrors, fmt.Errorf("promotion path %v needs at least two environments", path))
+		}
+	}
+	if config.SoakTime != "" {
+		if _, err := util.ParseDuration(config.SoakTime); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("promotion soak time is not valid: %s", err))
+		}
+	}
+	return validationErrors
+}
+
+//loadPromotionConfig returns the promotion config with the environments of the paths given by name or alias resolved to their names
+//The environments are resolved once per client, unknown environments are an error
+func loadPromotionConfig(cli *Cli) (*PromotionConfig, error) {
+	if cli.Client.promotion != nil {
+		return cli.Client.promotion, nil
+	}
+	config := &PromotionConfig{SoakTime: cli.Client.config.Promotion.SoakTime}
+	for _, path := range cli.Client.config.Promotion.Paths {
+		resolved := make([]string, len(path))
+		for i, environment := range path {
+			name, err := ResolveEnvironment(cli, environment)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid promotion path %v: %v", path, err)
+			}
+			resolved[i] = name
+		}
+		config.Paths = append(config.Paths, resolved)
+	}
+	cli.Client.promotion = config
+	return config, nil
+}
+
+//findPath returns the first path with a promotion from one environment to the next, nil if not allowed
+func (config *PromotionConfig) findPath(fromEnvironment string, toEnvironment string) []string {
+	for _, path := range config.Paths {
+		for i := 1; i < len(path); i++ {
+			if path[i-1] == fromEnvironment && path[i] == toEnvironment {
+				return path[:i+1]
+			}
+		}
+	}
+	return nil
+}
+
+//nextEnvironments returns the environments an environment can be promoted to
+func (config *PromotionConfig) nextEnvironments(environment string) []string {
+	next := []string{}
+	for _, path := range config.Paths {
+		for i := 1; i < len(path); i++ {
+			if path[i-1] == environment && !util.Contains(path[i], next) {
+				next = append(next, path[i])
+			}
+		}
+	}
+	return next
+}
+
+//checkPromotionPath checks that the deployments of an environment may be promoted to the target environment
+//With a soak time, the versions of each deployment have to be successful on every intermediate environment of the path (all except the first and the target) for at least the soak time
+//The violations of the soak time are returned by app server name, a promotion not allowed by the paths is an error
+func checkPromotionPath(cli *Cli, fromEnvironment string, toEnvironment string, deployments Deployments) (map[string]string, error) {
+	violations := map[string]string{}
+	if len(cli.Client.config.Promotion.Paths) == 0 {
+		return violations, nil
+	}
+	config, err := loadPromotionConfig(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	path := config.findPath(fromEnvironment, toEnvironment)
+	if path == nil {
+		next := config.nextEnvironments(fromEnvironment)
+		if len(next) == 0 {
+			return nil, fmt.Errorf("Promotion from environment %s to %s is not allowed by the promotion paths, environment %s can't be promoted", fromEnvironment, toEnvironment, fromEnvironment)
+		}
+		return nil, fmt.Errorf("Promotion from environment %s to %s is not allowed by the promotion paths, allowed from %s: %s", fromEnvironment, toEnvironment, fromEnvironment, strings.Join(next, ", "))
+	}
+	if config.SoakTime == "" || len(deployments) == 0 {
+		return violations, nil
+	}
+	soakTime, err := util.ParseDuration(config.SoakTime)
+	if err != nil {
+		return nil, err
+	}
+
+	appServers := make([]string, len(deployments))
+	for i, deployment := range deployments {
+		appServers[i] = deployment.AppServerName
+	}
+	now := time.Now()
+	for _, stage := range path[1 : len(path)-1] {
+		//One request per stage for the successful deployments of all promoted app servers
+		stageDeployments, err := GetDeployment(cli, &CommandOptionsGetDeployment{
+			AppServer:       appServers,
+			Environment:     []string{stage},
+			DeploymentState: []DeploymentState{DeploymentStateSuccess},
+			TrackingID:      -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		byAppServer := map[string]Deployments{}
+		for _, stageDeployment := range stageDeployments {
+			byAppServer[stageDeployment.AppServerName] = append(byAppServer[stageDeployment.AppServerName], stageDeployment)
+		}
+		for i := range deployments {
+			if _, found := violations[deployments[i].AppServerName]; found {
+				continue
+			}
+			if violation := checkSoakTime(&deployments[i], stage, byAppServer[deployments[i].AppServerName], soakTime, now); violation != "" {
+				violations[deployments[i].AppServerName] = violation
+			}
+		}
+	}
+	return violations, nil
+}
+
+//checkSoakTime returns a violation, if the versions of the deployment are not successful on the stage since at least the soak time
+//The stage deployments are the successful deployments of the app server on the stage
+func checkSoakTime(deployment *DeploymentResponse, stage string, stageDeployments Deployments, soakTime time.Duration, now time.Time) string {
+	var since time.Time
+	for _, stageDeployment := range stageDeployments {
+		if len(compareVersions(stageDeployment.versions(), deployment.versions())) > 0 {
+			continue
+		}
+		if t := stageDeployment.DeploymentTime(); since.IsZero() || t.Before(since) {
+			since = t
+		}
+	}
+	if since.IsZero() {
+		return fmt.Sprintf("versions never successful on environment %s", stage)
+	}
+	if now.Sub(since) < soakTime {
+		return fmt.Sprintf("versions successful on environment %s since %s", stage, since.Format(DisplayDateTimeFormat))
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckPromotionPath(t *testing.T) {
+
+	var tests = []struct {
+		name        string
+		from        string
+		to          string
+		expectedErr string
+	}{
+		{"next environment", "B", "P", ""},
+		{"other path", "T", "Z", ""},
+		{"skipping environments", "D", "P", "Promotion from environment D to P is not allowed by the promotion paths, allowed from D: T"},
+		{"backwards", "P", "B", "Promotion from environment P to B is not allowed by the promotion paths, environment P can't be promoted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// given
+			config := Config{Promotion: PromotionConfig{Paths: [][]string{{"Development", "T", "I", "B", "prod-ch"}, {"D", "T", "Z"}}, SoakTime: "1d"}}
+			cli := Cli{}
+			cli.Client, _ = NewMockClient(&config)
+			deployments := Deployments{newTestDeployment(1, 0, DeploymentStateSuccess, "testapp", "1.0")}
+
+			// when
+			_, err := checkPromotionPath(&cli, tt.from, tt.to, deployments)
+
+			// then
+			if tt.expectedErr == "" && err != nil {
+				t.Fatalf("Excepting no error: %s", err)
+			}
+			if tt.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("Excepting an error")
+				}
+				assertString(t, tt.expectedErr, err.Error(), "error")
+			}
+		})
+	}
+}
+
+func TestCheckPromotionPathUnknownEnvironment(t *testing.T) {
+
+	// given
+	config := Config{Promotion: PromotionConfig{Paths: [][]string{{"D", "T", "X"}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := checkPromotionPath(&cli, "D", "T", Deployments{})
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Invalid promotion path [D T X]: unknown environment X") {
+		t.Errorf("Expecting error unknown environment X, got %v", err)
+	}
+}
+
+func TestCheckSoakTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	hoursAgo := func(hours int) int64 {
+		return now.Add(-time.Duration(hours)*time.Hour).UnixNano() / int64(time.Millisecond)
+	}
+	deployment := newTestDeployment(1, hoursAgo(1), DeploymentStateSuccess, "app", "2.0")
+
+	var tests = []struct {
+		name             string
+		stageDeployments Deployments
+		expected         string
+	}{
+		{"soaked", Deployments{
+			newTestDeployment(2, hoursAgo(10), DeploymentStateSuccess, "app", "2.0"),
+			newTestDeployment(3, hoursAgo(30), DeploymentStateSuccess, "app", "2.0"),
+		}, ""},
+		{"not soaked", Deployments{
+			newTestDeployment(2, hoursAgo(10), DeploymentStateSuccess, "app", "2.0"),
+			newTestDeployment(3, hoursAgo(30), DeploymentStateSuccess, "app", "1.0"),
+		}, "versions successful on environment I since " + now.Add(-10*time.Hour).In(time.Local).Format(DisplayDateTimeFormat)},
+		{"never deployed", Deployments{
+			newTestDeployment(3, hoursAgo(30), DeploymentStateSuccess, "app", "1.0"),
+		}, "versions never successful on environment I"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			violation := checkSoakTime(&deployment, "I", tt.stageDeployments, 24*time.Hour, now)
+
+			// then
+			if !strings.HasPrefix(violation, tt.expected) || (tt.expected == "" && violation != "") {
+				t.Errorf("Expecting %q, got %q", tt.expected, violation)
+			}
+		})
+	}
+}
+
+func TestPromoteDeploymentsPromotionPath(t *testing.T) {
+
+	// given
+	config := Config{Promotion: PromotionConfig{Paths: [][]string{{"D", "T", "I", "B", "P"}}}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "D", Environment: "P"})
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Promotion from environment D to P is not allowed") {
+		t.Fatalf("Expecting promotion path error, got: %v", err)
+	}
+}
+
+func TestPromoteDeploymentsExcludesSoakTimeFailures(t *testing.T) {
+
+	// given
+	config := Config{Promotion: PromotionConfig{Paths: [][]string{{"D", "T", "I", "B", "P"}}, SoakTime: "100000d"}}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	deployments, err := PromoteDeployments(&cli, &CommandOptionsPromoteDeployments{FromEnvironment: "B", Environment: "P"})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 1 {
+		t.Fatalf("Expecting one deployment, got %v", deployments)
+	}
+	assertString(t, string(DeploymentStateExcluded), string(deployments[0].State), "state")
+	assertString(t, "P", deployments[0].EnvironmentName, "environment")
+	if !strings.HasPrefix(deployments[0].StatusMessage, "soak time of the promotion path not reached, versions ") {
+		t.Errorf("Expecting soak time status message, got %q", deployments[0].StatusMessage)
+	}
+}
@@ -0,0 +1,402 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+	"gopkg.in/yaml.v3"
+)
+
+//PropertyContextGlobal is the context of the values defined globally, inherited by all domains and environments
+const PropertyContextGlobal = "Global"
+
+//Properties is a collection of Property
+type Properties []Property
+
+//Property is the effective value of a property of a resource (or relation) on an environment
+//Liima doesn't return the resource type, resource or relation defining the property, only the context of the value
+type Property struct {
+	Name          string `json:"name"`
+	Value         string `json:"value"`         //effective value on the environment
+	Context       string `json:"context"`       //environment, domain or "Global" where the value is defined (environment inheritance)
+	ReplacedValue string `json:"replacedValue"` //inherited value overridden by the value, empty if the value is not overridden
+	Description   string `json:"generalComment"`
+	ValueComment  string `json:"valueComment"`
+}
+
+//Overridden returns true if the value overrides an inherited value
+func (property *Property) Overridden() bool {
+	return property.ReplacedValue != ""
+}
+
+//Inherited returns true if the value is not defined on the environment but inherited from a domain or globally
+func (property *Property) Inherited(environment string) bool {
+	return property.Context != environment
+}
+
+//sort.Interface
+func (slice Properties) Len() int {
+	return len(slice)
+}
+
+func (slice Properties) Less(i, j int) bool {
+	return slice[i].Name < slice[j].Name
+}
+
+func (slice Properties) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+//PropertyTarget identifies the properties of a release of a resource or of one of its relations on an environment
+type PropertyTarget struct {
+	Resource    string `json:"resource" yaml:"resource"`
+	Release     string `json:"release" yaml:"release"` //last release of the resource if empty
+	Environment string `json:"environment" yaml:"environment"`
+	Relation    string `json:"relation,omitempty" yaml:"relation,omitempty"` //name of the related resource, properties of the resource if empty
+}
+
+//PropertyFileEntry is an entry of a property file to set the properties of a target
+type PropertyFileEntry struct {
+	PropertyTarget `yaml:",inline"`
+	Properties     map[string]string `yaml:"properties"`
+}
+
+//PropertyChange is the change of a property value
+type PropertyChange struct {
+	PropertyTarget
+	Name          string `json:"name"`
+	Value         string `json:"value"`         //new value, empty if unset
+	PreviousValue string `json:"previousValue"` //effective value before the change
+	Unset         bool   `json:"unset"`         //the value is reset to the inherited value
+}
+
+//CommandOptionsGetProperty used for the command options (flags)
+type CommandOptionsGetProperty struct {
+	PropertyTarget
+	Name []string `json:"name"` //Name patterns (glob "jvm.*" or regex "re:^jvm\."), all if empty
+}
+
+//CommandOptionsSetProperty used for the command options (flags)
+type CommandOptionsSetProperty struct {
+	PropertyTarget
+	Values []string `json:"values"` //Values "key=value"
+	File   string   `json:"file"`   //Property file (YAML) with the values of one or more targets, the target flags are the defaults
+}
+
+//CommandOptionsUnsetProperty used for the command options (flags)
+type CommandOptionsUnsetProperty struct {
+	PropertyTarget
+	Name []string `json:"name"`
+}
+
+//Validate the given target
+func (target *PropertyTarget) validate(cli *Cli, errorList *[]string) {
+	util.Check(errorList, target.Resource != "", "want resource")
+	checkEnvironment(cli, errorList, &target.Environment, "environment")
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsGetProperty) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	commandOption.PropertyTarget.validate(cli, &errorList)
+	for _, err := range util.ValidatePatterns(commandOption.Name) {
+		errorList = append(errorList, err.Error())
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsUnsetProperty) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	commandOption.PropertyTarget.validate(cli, &errorList)
+	util.Check(&errorList, len(commandOption.Name) > 0, "want at least one property name")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//entries returns the entries of the property file and of the values, the targets of the file entries default to the target of the command options
+func (commandOption *CommandOptionsSetProperty) entries() ([]PropertyFileEntry, error) {
+	entries := []PropertyFileEntry{}
+	if commandOption.File != "" {
+		var err error
+		if entries, err = readPropertyFile(commandOption.File, &commandOption.PropertyTarget); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(commandOption.Values) > 0 {
+		entry := PropertyFileEntry{PropertyTarget: commandOption.PropertyTarget, Properties: map[string]string{}}
+		for _, value := range commandOption.Values {
+			keyValue := strings.SplitN(value, "=", 2)
+			if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+				return nil, fmt.Errorf("want key=value, got %s", value)
+			}
+			entry.Properties[strings.TrimSpace(keyValue[0])] = keyValue[1]
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+//withDefaults returns the target with the empty fields taken from the defaults
+func (target PropertyTarget) withDefaults(defaults *PropertyTarget) PropertyTarget {
+	if target.Resource == "" {
+		target.Resource = defaults.Resource
+	}
+	if target.Release == "" {
+		target.Release = defaults.Release
+	}
+	if target.Environment == "" {
+		target.Environment = defaults.Environment
+	}
+	if target.Relation == "" {
+		target.Relation = defaults.Relation
+	}
+	return target
+}
+
+//readPropertyFile reads the entries of a property file, the targets of the entries default to the given target
+func readPropertyFile(name string, defaults *PropertyTarget) ([]PropertyFileEntry, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read property file: %v", err)
+	}
+	defer file.Close()
+	entries, err := ReadPropertyFile(file)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].PropertyTarget = entries[i].withDefaults(defaults)
+	}
+	return entries, nil
+}
+
+//ReadPropertyFile reads the entries of a property file (YAML)
+func ReadPropertyFile(r io.Reader) ([]PropertyFileEntry, error) {
+	entries := []PropertyFileEntry{}
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Couldn't read property file: %v", err)
+	}
+	return entries, nil
+}
+
+//propertiesURL resolves the release of the resource and of the relation and returns the url of their properties
+func (target *PropertyTarget) propertiesURL(cli *Cli) (string, error) {
+	var err error
+	if target.Release, err = resourceRelease(cli, target.Resource, target.Release); err != nil {
+		return "", err
+	}
+	propertiesURL := "resources/./resources/" + url.PathEscape(target.Resource) + "/" + url.PathEscape(target.Release)
+
+	if target.Relation != "" {
+		detail, err := GetResourceDetail(cli, target.Resource, target.Release)
+		if err != nil {
+			return "", err
+		}
+		relatedRelease := ""
+		for _, relation := range detail.Relations {
+			if relation.RelatedResourceName == target.Relation {
+				relatedRelease = relation.RelatedResourceRelease
+			}
+		}
+		if relatedRelease == "" {
+			return "", fmt.Errorf("Resource %s %s has no relation to %s", target.Resource, target.Release, target.Relation)
+		}
+		propertiesURL += "/relations/" + url.PathEscape(target.Relation) + "/" + url.PathEscape(relatedRelease)
+	}
+	return propertiesURL + "/properties", nil
+}
+
+//getProperties returns the properties of the target sorted by name
+func getProperties(cli *Cli, target *PropertyTarget, propertiesURL string) (Properties, error) {
+	properties := Properties{}
+	if err := cli.Client.DoRequest(http.MethodGet, propertiesURL+"?env="+url.QueryEscape(target.Environment), nil, &properties); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	sort.Sort(properties)
+	return properties, nil
+}
+
+//findProperty returns the property with the name
+func (slice Properties) findProperty(name string) (*Property, bool) {
+	for i := range slice {
+		if slice[i].Name == name {
+			return &slice[i], true
+		}
+	}
+	return nil, false
+}
+
+//GetProperties returns the effective properties of the target filtered by name
+func GetProperties(cli *Cli, commandOptions *CommandOptionsGetProperty) (Properties, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	propertiesURL, err := commandOptions.propertiesURL(cli)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := getProperties(cli, &commandOptions.PropertyTarget, propertiesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	//Filter name
+	if len(commandOptions.Name) == 0 {
+		return properties, nil
+	}
+	filtered := Properties{}
+	for _, property := range properties {
+		if _, found := util.FindPattern(property.Name, commandOptions.Name); found {
+			filtered = append(filtered, property)
+		}
+	}
+	return filtered, nil
+}
+
+//SetProperties sets the values of the properties on the environments and returns the changes
+//All changes are computed before the first change is applied, on an error the applied changes until the error are returned
+func SetProperties(cli *Cli, commandOptions *CommandOptionsSetProperty) ([]PropertyChange, error) {
+
+	entries, err := commandOptions.entries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("want at least one key=value or a property file")
+	}
+
+	//Validate all entries before the first change
+	var errorList []string
+	for i := range entries {
+		entries[i].PropertyTarget.validate(cli, &errorList)
+		util.Check(&errorList, len(entries[i].Properties) > 0, "want properties of resource %s", entries[i].Resource)
+	}
+	if len(errorList) > 0 {
+		return nil, errors.New(strings.Join(errorList, ", "))
+	}
+
+	//Compute the changes of all entries
+	urls := make([]string, len(entries))
+	entryChanges := make([][]PropertyChange, len(entries))
+	for i := range entries {
+		names := []string{}
+		for name := range entries[i].Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if urls[i], entryChanges[i], err = planPropertyChanges(cli, &entries[i].PropertyTarget, names, entries[i].Properties); err != nil {
+			return nil, err
+		}
+	}
+	return applyEntryChanges(cli, urls, entryChanges)
+}
+
+//UnsetProperties resets the values of the properties on the environment to the inherited values and returns the changes
+func UnsetProperties(cli *Cli, commandOptions *CommandOptionsUnsetProperty) ([]PropertyChange, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+	propertiesURL, changes, err := planPropertyChanges(cli, &commandOptions.PropertyTarget, commandOptions.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return applyPropertyChanges(cli, propertiesURL, changes)
+}
+
+//planPropertyChanges returns the url of the properties of the target and the changes to the values without applying them
+//The properties are unset if values is nil
+func planPropertyChanges(cli *Cli, target *PropertyTarget, names []string, values map[string]string) (string, []PropertyChange, error) {
+	propertiesURL, err := target.propertiesURL(cli)
+	if err != nil {
+		return "", nil, err
+	}
+	properties, err := getProperties(cli, target, propertiesURL)
+	if err != nil {
+		return "", nil, err
+	}
+	changes, err := propertyChanges(target, properties, names, values, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return propertiesURL, changes, nil
+}
+
+//propertyChanges returns the changes of the properties to the values, the properties are unset if values is nil
+//Values already defined on the environment are skipped if onlyChanged is set
+func propertyChanges(target *PropertyTarget, properties Properties, names []string, values map[string]string, onlyChanged bool) ([]PropertyChange, error) {
+	changes := []PropertyChange{}
+	for _, name := range names {
+		property, found := properties.findProperty(name)
+		if !found {
+			return nil, fmt.Errorf("Unknown property %s of resource %s %s", name, target.Resource, target.Release)
+		}
+		//Only values defined on the environment can be unset, inherited values are defined on another context
+		if values == nil && property.Inherited(target.Environment) {
+			return nil, fmt.Errorf("Property %s of resource %s %s has no value on environment %s, the value is inherited from %s", name, target.Resource, target.Release, target.Environment, property.Context)
+		}
+		change := PropertyChange{PropertyTarget: *target, Name: name, Value: values[name], PreviousValue: property.Value, Unset: values == nil}
+		if onlyChanged && !change.Unset && !property.Inherited(target.Environment) && property.Value == change.Value {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+//applyEntryChanges applies the changes of several targets with the urls of their properties, on an error the changes until the error are returned
+func applyEntryChanges(cli *Cli, urls []string, entryChanges [][]PropertyChange) ([]PropertyChange, error) {
+	changes := []PropertyChange{}
+	for i := range entryChanges {
+		applied, err := applyPropertyChanges(cli, urls[i], entryChanges[i])
+		changes = append(changes, applied...)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+//applyPropertyChanges applies the changes of the properties, on an error the changes until the error are returned
+func applyPropertyChanges(cli *Cli, propertiesURL string, changes []PropertyChange) ([]PropertyChange, error) {
+	for i, change := range changes {
+		var err error
+		propertyURL := propertiesURL + "/" + url.PathEscape(change.Name) + "?env=" + url.QueryEscape(change.Environment)
+		if change.Unset {
+			err = cli.Client.DoRequest(http.MethodDelete, propertyURL, nil, nil)
+		} else {
+			err = cli.Client.DoRequest(http.MethodPut, propertyURL, &Property{Name: change.Name, Value: change.Value}, nil)
+		}
+		if err != nil {
+			return changes[:i], fmt.Errorf("Error in rest call: %v", err)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetPropertiesFromFile(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	file := filepath.Join(t.TempDir(), "properties.yaml")
+	os.WriteFile(file, []byte("- resource: Test\n  release: RL-18.10\n  properties:\n    jvm.heap.max: 4096m\n- resource: Test\n  relation: testapp\n  properties:\n    url: http://other\n"), 0644)
+
+	// when
+	changes, err := SetProperties(&cli, &CommandOptionsSetProperty{PropertyTarget: PropertyTarget{Environment: "PROD-CH"}, File: file})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expecting two changes, got %v", changes)
+	}
+	assertString(t, "RL-18.10", changes[0].Release, "release")
+	assertString(t, "P", changes[0].Environment, "environment")
+	assertString(t, "2048m", changes[0].PreviousValue, "previous value")
+	assertString(t, "RL-19.04", changes[1].Release, "last release")
+	assertString(t, "http://other", changes[1].Value, "value")
+}
+
+func TestSetUnknownProperty(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	changes, err := SetProperties(&cli, &CommandOptionsSetProperty{PropertyTarget: PropertyTarget{Resource: "Test", Environment: "T"}, Values: []string{"jvm.heap.max=1g", "jvm.heap.size=1g"}})
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Unknown property jvm.heap.size") {
+		t.Fatalf("Expecting an unknown property error, got %v", err)
+	}
+	//The values are checked before the first change
+	if len(changes) != 0 {
+		t.Fatalf("Expecting no change, got %v", changes)
+	}
+}
+
+func TestSetPropertiesComputesAllChangesFirst(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	file := filepath.Join(t.TempDir(), "properties.yaml")
+	os.WriteFile(file, []byte("- resource: Test\n  properties:\n    jvm.heap.max: 4096m\n- resource: Test\n  relation: testapp\n  properties:\n    unknown: value\n"), 0644)
+
+	// when
+	changes, err := SetProperties(&cli, &CommandOptionsSetProperty{PropertyTarget: PropertyTarget{Environment: "T"}, File: file})
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Unknown property unknown") {
+		t.Fatalf("Expecting an unknown property error, got %v", err)
+	}
+	//The valid first entry isn't applied
+	if len(changes) != 0 {
+		t.Fatalf("Expecting no change, got %v", changes)
+	}
+}
+
+func TestUnsetInheritedProperty(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	changes, err := UnsetProperties(&cli, &CommandOptionsUnsetProperty{PropertyTarget: PropertyTarget{Resource: "Test", Environment: "T"}, Name: []string{"jvm.heap.max", "jvm.heap.min"}})
+
+	// then
+	if err == nil || err.Error() != "Property jvm.heap.min of resource Test RL-19.04 has no value on environment T, the value is inherited from Global" {
+		t.Fatalf("Expecting an inherited property error, got %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expecting no change, got %v", changes)
+	}
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+	"gopkg.in/yaml.v3"
+)
+
+//CommandOptionsExportProperty used for the command options (flags)
+type CommandOptionsExportProperty struct {
+	Resource    string   `json:"resource"`
+	Release     string   `json:"release"`     //last release of the resource if empty
+	Environment []string `json:"environment"` //all environments if empty
+	Contexts    bool     `json:"contexts"`    //also export the values defined globally and on the domains of the environments
+}
+
+//CommandOptionsImportProperty used for the command options (flags)
+type CommandOptionsImportProperty struct {
+	PropertyTarget        //Defaults of the entries of the property file
+	File           string `json:"file"`   //Property file (YAML), for example written by export
+	DryRun         bool   `json:"dryRun"` //Only compute the changes, don't apply them
+	Prune          bool   `json:"prune"`  //Unset the values defined on the environment but missing in the property file
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsExportProperty) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.Resource != "", "want resource")
+	for i := range commandOption.Environment {
+		checkEnvironment(cli, &errorList, &commandOption.Environment[i], "environment")
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//checkContext replaces the context given by name with its name, an error is added to the errorList if it is missing or unknown
+//The context is "Global", a domain or an environment given by name or alias
+func checkContext(cli *Cli, errorList *[]string, context *string, field string) {
+	if environments, err := GetEnvironments(cli); err == nil {
+		for _, name := range append([]string{PropertyContextGlobal}, environments.Domains()...) {
+			if strings.EqualFold(strings.TrimSpace(*context), name) {
+				*context = name
+				return
+			}
+		}
+	}
+	checkEnvironment(cli, errorList, context, field)
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsImportProperty) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.File != "", "want property file")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//ExportProperties returns for each environment the property values of the resource and its relations defined on the environment
+//With Contexts, the values defined globally and on the domains of the environments are exported as entries of their context before the environments,
+//otherwise these inherited values are not exported. Targets without values are skipped
+func ExportProperties(cli *Cli, commandOptions *CommandOptionsExportProperty) ([]PropertyFileEntry, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	all, err := GetEnvironments(cli)
+	if err != nil {
+		return nil, err
+	}
+	selected := Environments{}
+	for _, environment := range all {
+		if len(commandOptions.Environment) == 0 || util.Contains(environment.Name, commandOptions.Environment) {
+			selected = append(selected, environment)
+		}
+	}
+	contexts := []string{}
+	if commandOptions.Contexts {
+		contexts = append([]string{PropertyContextGlobal}, selected.Domains()...)
+	}
+	for _, environment := range selected {
+		contexts = append(contexts, environment.Name)
+	}
+
+	//The urls of the resource and of its relations are the same on each environment
+	target := PropertyTarget{Resource: commandOptions.Resource, Release: commandOptions.Release}
+	propertiesURL, err := target.propertiesURL(cli)
+	if err != nil {
+		return nil, err
+	}
+	targets := []PropertyTarget{target}
+	urls := []string{propertiesURL}
+	detail, err := GetResourceDetail(cli, target.Resource, target.Release)
+	if err != nil {
+		return nil, err
+	}
+	for _, relation := range detail.Relations {
+		relationTarget := PropertyTarget{Resource: target.Resource, Release: target.Release, Relation: relation.RelatedResourceName}
+		relationURL, err := relationTarget.propertiesURL(cli)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, relationTarget)
+		urls = append(urls, relationURL)
+	}
+
+	entries := []PropertyFileEntry{}
+	for _, context := range contexts {
+		for i := range targets {
+			entry := PropertyFileEntry{PropertyTarget: targets[i], Properties: map[string]string{}}
+			entry.Environment = context
+			properties, err := getProperties(cli, &entry.PropertyTarget, urls[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, property := range properties {
+				if property.Context == context {
+					entry.Properties[property.Name] = property.Value
+				}
+			}
+			if len(entry.Properties) > 0 {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+//WritePropertyFile writes the entries as property file (YAML)
+func WritePropertyFile(w io.Writer, entries []PropertyFileEntry) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(entries); err != nil {
+		return fmt.Errorf("Couldn't write property file: %v", err)
+	}
+	return encoder.Close()
+}
+
+//ImportProperties computes the changes of the property file against the server and applies them, if it is no dry run
+//Values already defined on the environment are not changed. All changes are computed before the first change is applied,
+//on an error the applied changes until the error are returned
+func ImportProperties(cli *Cli, commandOptions *CommandOptionsImportProperty) ([]PropertyChange, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+	entries, err := readPropertyFile(commandOptions.File, &commandOptions.PropertyTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	//Validate all entries before the first request, the entries may also be of the global or a domain context
+	var errorList []string
+	for i := range entries {
+		util.Check(&errorList, entries[i].Resource != "", "want resource")
+		checkContext(cli, &errorList, &entries[i].Environment, "environment")
+	}
+	if len(errorList) > 0 {
+		return nil, errors.New(strings.Join(errorList, ", "))
+	}
+
+	//Compute the changes of all entries
+	urls := make([]string, len(entries))
+	entryChanges := make([][]PropertyChange, len(entries))
+	for i := range entries {
+		target := &entries[i].PropertyTarget
+		if urls[i], err = target.propertiesURL(cli); err != nil {
+			return nil, err
+		}
+		properties, err := getProperties(cli, target, urls[i])
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+		for name := range entries[i].Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if entryChanges[i], err = propertyChanges(target, properties, names, entries[i].Properties, true); err != nil {
+			return nil, err
+		}
+
+		//Unset the values of the environment missing in the property file
+		if commandOptions.Prune {
+			missing := []string{}
+			for _, property := range properties {
+				if _, found := entries[i].Properties[property.Name]; !found && !property.Inherited(target.Environment) {
+					missing = append(missing, property.Name)
+				}
+			}
+			unset, err := propertyChanges(target, properties, missing, nil, true)
+			if err != nil {
+				return nil, err
+			}
+			entryChanges[i] = append(entryChanges[i], unset...)
+		}
+	}
+
+	if commandOptions.DryRun {
+		changes := []PropertyChange{}
+		for i := range entryChanges {
+			changes = append(changes, entryChanges[i]...)
+		}
+		return changes, nil
+	}
+	return applyEntryChanges(cli, urls, entryChanges)
+}
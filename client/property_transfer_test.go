@@ -0,0 +1,57 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportPropertiesPrune(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	file := filepath.Join(t.TempDir(), "properties.yaml")
+	os.WriteFile(file, []byte("- resource: Test\n  environment: T\n  properties:\n    jvm.heap.min: 512m\n"), 0644)
+
+	// when
+	changes, err := ImportProperties(&cli, &CommandOptionsImportProperty{File: file, Prune: true})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expecting two changes, got %v", changes)
+	}
+	assertString(t, "jvm.heap.min", changes[0].Name, "set name")
+	assertString(t, "512m", changes[0].PreviousValue, "set previous value")
+	assertString(t, "jvm.heap.max", changes[1].Name, "unset name")
+	if !changes[1].Unset {
+		t.Fatalf("Expecting unset of jvm.heap.max, got %v", changes[1])
+	}
+}
+
+func TestImportPropertiesGlobalContext(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	file := filepath.Join(t.TempDir(), "properties.yaml")
+	os.WriteFile(file, []byte("- resource: Test\n  environment: global\n  properties:\n    jvm.heap.min: 1g\n- resource: Test\n  environment: Dev\n  properties:\n    jvm.heap.min: 768m\n"), 0644)
+
+	// when
+	changes, err := ImportProperties(&cli, &CommandOptionsImportProperty{File: file, DryRun: true})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expecting two changes, got %v", changes)
+	}
+	assertString(t, PropertyContextGlobal, changes[0].Environment, "global context")
+	assertString(t, "Dev", changes[1].Environment, "domain context")
+}
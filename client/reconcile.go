@@ -0,0 +1,253 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//CommandOptionsReconcile used for the command options (flags)
+type CommandOptionsReconcile struct {
+	DryRun               bool //Only report the drift, don't create deployments
+	RetryFailed          bool //Deploy again, if the latest deployment of the desired state failed
+	ExecuteShakedownTest bool `json:"executeShakedownTest"`
+	Wait                 bool //Wait as long the WaitTime until the deployment success or failed
+	MaxWaitTime          int  //Max wait time [seconds] until the deployment success or failed
+	PolicyOptions
+}
+
+// DriftReason is the reason why an app server is not in its desired state
+type DriftReason string
+
+//Enumeration of drift reasons
+const (
+	DriftReasonNone        DriftReason = "none"        //in the desired state
+	DriftReasonNotDeployed DriftReason = "notDeployed" //no successful deployment
+	DriftReasonVersions    DriftReason = "versions"    //other application versions deployed
+	DriftReasonRelease     DriftReason = "release"     //same versions, other release deployed
+	DriftReasonPending     DriftReason = "pending"     //a deployment of the desired state is not finished
+	DriftReasonFailed      DriftReason = "failed"      //the latest deployment of the desired state failed
+)
+
+//AppServerDrift is the difference of an app server between its desired state and its latest successful deployment
+type AppServerDrift struct {
+	Environment    string              `json:"environment"`
+	AppServerName  string              `json:"appServerName"`
+	Reason         DriftReason         `json:"reason"`
+	ActualRelease  string              `json:"actualRelease"`
+	DesiredRelease string              `json:"desiredRelease"`
+	Changes        []VersionChange     `json:"changes"`              //changes from the actual to the desired versions
+	Deployment     *DeploymentResponse `json:"deployment,omitempty"` //deployment created to reconcile
+	Error          string              `json:"error,omitempty"`      //error on creating the deployment
+}
+
+//Validate the desired state
+func validateDesiredState(cli *Cli, desiredState []Snapshot) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, len(desiredState) > 0, "want at least one environment in the desired state")
+	for i := range desiredState {
+		checkEnvironment(cli, &errorList, &desiredState[i].Environment, "environment")
+		snapshot := desiredState[i]
+		for _, appServer := range snapshot.AppServers {
+			util.Check(&errorList, appServer.Name != "", "want name of each appServer on environment %s", snapshot.Environment)
+			util.Check(&errorList, len(appServer.Applications) > 0, "want applications of appServer %s on environment %s", appServer.Name, snapshot.Environment)
+		}
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetDrift compares the desired state of each app server with its latest successful and latest deployment
+func GetDrift(cli *Cli, desiredState []Snapshot) ([]AppServerDrift, error) {
+
+	if err := validateDesiredState(cli, desiredState); err != nil {
+		return nil, err
+	}
+
+	drifts := []AppServerDrift{}
+	for _, snapshot := range desiredState {
+		appServers := []string{}
+		for _, appServer := range snapshot.AppServers {
+			appServers = append(appServers, appServer.Name)
+		}
+
+		successful, err := getLatestSuccessfulDeployments(cli, snapshot.Environment, appServers)
+		if err != nil {
+			return nil, err
+		}
+		latest, err := GetDeployment(cli, &CommandOptionsGetDeployment{
+			AppServer:   appServers,
+			Environment: []string{snapshot.Environment},
+			OnlyLatest:  true,
+			TrackingID:  -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, appServer := range snapshot.AppServers {
+			drift := compareDesiredState(&appServer, findDeployment(successful, appServer.Name), findDeployment(latest, appServer.Name))
+			drift.Environment = snapshot.Environment
+			drifts = append(drifts, drift)
+		}
+	}
+	return drifts, nil
+}
+
+//Reconcile creates a deployment for each app server which is not in its desired state
+//App servers with a pending or failed deployment of the desired state are not deployed again (except RetryFailed)
+func Reconcile(cli *Cli, commandOptions *CommandOptionsReconcile, desiredState []Snapshot) ([]AppServerDrift, error) {
+
+	drifts, err := GetDrift(cli, desiredState)
+	if err != nil || commandOptions.DryRun {
+		return drifts, err
+	}
+
+	//Check the policy once per environment before creating its deployments, a violation is the error of each drift of the environment
+	deploymentDates := map[string]string{}
+	policyErrors := map[string]error{}
+	for _, drift := range drifts {
+		if _, checked := deploymentDates[drift.Environment]; checked || !drift.needsDeployment(commandOptions.RetryFailed) {
+			continue
+		}
+		deploymentDates[drift.Environment], policyErrors[drift.Environment] = checkPolicyOnce(cli, "reconcile", drift.Environment, "", "", &commandOptions.PolicyOptions)
+	}
+
+	createdDeployments := map[string]Deployments{}
+	for i := range drifts {
+		drift := &drifts[i]
+		if !drift.needsDeployment(commandOptions.RetryFailed) {
+			continue
+		}
+		if err := policyErrors[drift.Environment]; err != nil {
+			drift.Error = err.Error()
+			continue
+		}
+
+		desired := findDesiredAppServer(desiredState, drift.Environment, drift.AppServerName)
+		commandOptionsCreateDeployment := desired.createOptions(drift.Environment)
+		commandOptionsCreateDeployment.DeploymentDate = deploymentDates[drift.Environment]
+		commandOptionsCreateDeployment.ExecuteShakedownTest = commandOptions.ExecuteShakedownTest
+		commandOptionsCreateDeployment.policyChecked = true
+		deployment, err := CreateDeployment(cli, &commandOptionsCreateDeployment)
+		if err != nil {
+			drift.Error = err.Error()
+			continue
+		}
+		drift.Deployment = deployment
+		createdDeployments[drift.Environment] = append(createdDeployments[drift.Environment], *deployment)
+	}
+
+	//Wait on deployment success or failed
+	if commandOptions.Wait {
+		for environment, deployments := range createdDeployments {
+			finishedDeployments, err := waitForDeployments(cli, environment, deployments, commandOptions.MaxWaitTime)
+			if err != nil {
+				return drifts, err
+			}
+			for i := range drifts {
+				if drifts[i].Deployment != nil && drifts[i].Environment == environment {
+					if finished := findDeployment(finishedDeployments, drifts[i].AppServerName); finished != nil {
+						drifts[i].Deployment = finished
+					}
+				}
+			}
+		}
+	}
+
+	//Return an error, if not all deployments could be created, a policy violation is returned as cause
+	for _, drift := range drifts {
+		if drift.Error != "" {
+			if policyErr := policyErrors[drift.Environment]; policyErr != nil {
+				return drifts, fmt.Errorf("Not all deployments could be created, first error on app server %s: %w", drift.AppServerName, policyErr)
+			}
+			return drifts, fmt.Errorf("Not all deployments could be created, first error on app server %s: %s", drift.AppServerName, drift.Error)
+		}
+	}
+	return drifts, nil
+}
+
+//needsDeployment returns true if the app server has to be deployed to reach its desired state
+func (drift *AppServerDrift) needsDeployment(retryFailed bool) bool {
+	switch drift.Reason {
+	case DriftReasonNotDeployed, DriftReasonVersions, DriftReasonRelease:
+		return true
+	case DriftReasonFailed:
+		return retryFailed
+	}
+	return false
+}
+
+//compareDesiredState compares the desired state of an app server with its latest successful and latest deployment, nil if not deployed
+func compareDesiredState(desired *SnapshotAppServer, successful *DeploymentResponse, latest *DeploymentResponse) AppServerDrift {
+	drift := AppServerDrift{
+		AppServerName:  desired.Name,
+		DesiredRelease: desired.Release,
+		Reason:         DriftReasonNone,
+	}
+	desiredVersions := map[string]string{}
+	for _, app := range desired.Applications {
+		desiredVersions[app.Name] = app.Version
+	}
+
+	//Compare with the latest successful deployment
+	switch {
+	case successful == nil:
+		drift.Changes = compareVersions(map[string]string{}, desiredVersions)
+		drift.Reason = DriftReasonNotDeployed
+	default:
+		drift.ActualRelease = successful.ReleaseName
+		drift.Changes = compareVersions(successful.versions(), desiredVersions)
+		if len(drift.Changes) > 0 {
+			drift.Reason = DriftReasonVersions
+		} else if desired.Release != "" && desired.Release != successful.ReleaseName {
+			drift.Reason = DriftReasonRelease
+		}
+	}
+
+	//A newer deployment of the desired versions is pending or failed
+	if drift.Reason != DriftReasonNone && latest != nil && (successful == nil || latest.ID != successful.ID) && len(compareVersions(latest.versions(), desiredVersions)) == 0 {
+		switch latest.State {
+		case DeploymentStateFailed:
+			drift.Reason = DriftReasonFailed
+		case DeploymentStateCanceled, DeploymentStateRejected, DeploymentStateSuccess:
+		default:
+			drift.Reason = DriftReasonPending
+		}
+	}
+	return drift
+}
+
+//findDeployment returns the deployment of an app server, nil if not found
+func findDeployment(deployments Deployments, appServerName string) *DeploymentResponse {
+	for i := range deployments {
+		if deployments[i].AppServerName == appServerName {
+			return &deployments[i]
+		}
+	}
+	return nil
+}
+
+//findDesiredAppServer returns the desired state of an app server on an environment
+func findDesiredAppServer(desiredState []Snapshot, environment string, appServerName string) *SnapshotAppServer {
+	for i := range desiredState {
+		if desiredState[i].Environment != environment {
+			continue
+		}
+		for j := range desiredState[i].AppServers {
+			if desiredState[i].AppServers[j].Name == appServerName {
+				return &desiredState[i].AppServers[j]
+			}
+		}
+	}
+	return nil
+}
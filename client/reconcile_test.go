@@ -0,0 +1,102 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareDesiredState(t *testing.T) {
+
+	// given
+	desired := SnapshotAppServer{Name: "Test", Release: "RL-2", Applications: []SnapshotApplication{{"app1", "1.1"}}}
+	successfulOld := newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0")
+	successfulOld.ReleaseName = "RL-2"
+	successfulDesired := newTestDeployment(2, 2000, DeploymentStateSuccess, "app1", "1.1")
+	successfulDesired.ReleaseName = "RL-1"
+	pending := newTestDeployment(3, 3000, DeploymentStateProgress, "app1", "1.1")
+	failed := newTestDeployment(4, 4000, DeploymentStateFailed, "app1", "1.1")
+	failedOther := newTestDeployment(5, 5000, DeploymentStateFailed, "app1", "1.2")
+
+	//Tests
+	tests := []struct {
+		name       string              //Name of the test
+		successful *DeploymentResponse //Argument
+		latest     *DeploymentResponse //Argument
+		want       DriftReason         //Wanted testresult
+	}{
+		{"NotDeployed", nil, nil, DriftReasonNotDeployed},
+		{"Versions", &successfulOld, &successfulOld, DriftReasonVersions},
+		{"Release", &successfulDesired, &successfulDesired, DriftReasonRelease},
+		{"Pending", &successfulOld, &pending, DriftReasonPending},
+		{"PendingFirst", nil, &pending, DriftReasonPending},
+		{"Failed", &successfulOld, &failed, DriftReasonFailed},
+		{"FailedOtherVersions", &successfulOld, &failedOther, DriftReasonVersions},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareDesiredState(&desired, tt.successful, tt.latest); got.Reason != tt.want {
+				t.Errorf("compareDesiredState() = %v, want %v", got.Reason, tt.want)
+			}
+		})
+	}
+
+	desired.Release = ""
+	if got := compareDesiredState(&desired, &successfulDesired, &successfulDesired); got.Reason != DriftReasonNone {
+		t.Errorf("compareDesiredState() without release = %v, want %v", got.Reason, DriftReasonNone)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	desiredState, err := ReadSnapshots(strings.NewReader(`
+environment: T
+appServers:
+  - name: Test
+    applications:
+      - name: testapp
+        version: "1.0"
+---
+environment: Y
+appServers:
+  - name: Test
+    applications:
+      - name: testapp
+        version: "1.1"
+`))
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// when
+	dryRun, err := Reconcile(&cli, &CommandOptionsReconcile{DryRun: true}, desiredState)
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	drifts, err := Reconcile(&cli, &CommandOptionsReconcile{Wait: true, MaxWaitTime: 60}, desiredState)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(drifts) != 2 || len(dryRun) != 2 {
+		t.Fatalf("Expecting 2 drifts, got %v", drifts)
+	}
+	assertString(t, string(DriftReasonNone), string(drifts[0].Reason), "reason")
+	assertString(t, string(DriftReasonVersions), string(drifts[1].Reason), "reason")
+	if dryRun[1].Deployment != nil {
+		t.Errorf("Expecting no deployment on dry run")
+	}
+	if drifts[0].Deployment != nil || drifts[1].Deployment == nil || drifts[1].Deployment.State != DeploymentStateSuccess {
+		t.Errorf("Expecting a successful deployment only for the drift, got %v and %v", drifts[0].Deployment, drifts[1].Deployment)
+	}
+
+	if _, err := Reconcile(&cli, &CommandOptionsReconcile{}, []Snapshot{}); err == nil {
+		t.Errorf("Expecting an error on an empty desired state")
+	}
+}
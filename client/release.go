@@ -0,0 +1,183 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//Release aliases resolved by ResolveRelease
+const (
+	ReleaseLatest = "latest" //the release with the latest installation date in the past (current production release)
+	ReleaseNext   = "next"   //the release with the earliest installation date in the future
+)
+
+//Releases is a collection of Release
+type Releases []Release
+
+//Release is a Liima release
+type Release struct {
+	ID                         int    `json:"id"`
+	Name                       string `json:"name"`
+	MainRelease                bool   `json:"mainRelease"`
+	Description                string `json:"description"`
+	InstallationInProductionAt int64  `json:"installationInProductionAt"` //Installation date in production [ms since 1970]
+}
+
+//InstallationTime returns the installation date in production in the local timezone
+func (release *Release) InstallationTime() time.Time {
+	return time.Unix(0, release.InstallationInProductionAt*int64(time.Millisecond)).In(time.Local)
+}
+
+//sort.Interface, sorted by installation date
+func (slice Releases) Len() int {
+	return len(slice)
+}
+
+func (slice Releases) Less(i, j int) bool {
+	return slice[i].InstallationInProductionAt < slice[j].InstallationInProductionAt
+}
+
+func (slice Releases) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+//CommandOptionsCreateRelease used for the command options (flags)
+type CommandOptionsCreateRelease struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	InstallationDate string `json:"installationDate"` //Installation date in production "YYYY-MM-DD"
+	MainRelease      bool   `json:"mainRelease"`
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsCreateRelease) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, strings.TrimSpace(commandOption.Name) != "", "want release name")
+	util.Check(&errorList, commandOption.Name != ReleaseLatest && commandOption.Name != ReleaseNext, "want release name other than the aliases %s and %s", ReleaseLatest, ReleaseNext)
+	if _, _, err := util.ParseDay(commandOption.InstallationDate, time.Local); err != nil {
+		errorList = append(errorList, "installation date: "+err.Error())
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetReleases returns all releases sorted by installation date
+//The releases are read once per client, they are also used to resolve the release of deployments
+func GetReleases(cli *Cli) (Releases, error) {
+	if cli.Client.releases != nil {
+		return cli.Client.releases, nil
+	}
+
+	//Call rest client
+	releases := Releases{}
+	if err := cli.Client.DoRequest(http.MethodGet, "resources/./releases", nil, &releases); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	sort.Stable(releases)
+	cli.Client.releases = releases
+	return releases, nil
+}
+
+//GetRelease returns a release by its name or alias ("latest", "next")
+func GetRelease(cli *Cli, name string) (*Release, error) {
+	if name == ReleaseLatest || name == ReleaseNext {
+		releases, err := GetReleases(cli)
+		if err != nil {
+			return nil, err
+		}
+		return findRelease(releases, name, time.Now())
+	}
+
+	release := &Release{}
+	if err := cli.Client.DoRequest(http.MethodGet, "resources/./releases/"+url.PathEscape(name), nil, release); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	return release, nil
+}
+
+//CreateRelease creates a release and returns it
+func CreateRelease(cli *Cli, commandOptions *CommandOptionsCreateRelease) (*Release, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+
+	installationDate, _, _ := util.ParseDay(commandOptions.InstallationDate, time.Local)
+	request := Release{
+		Name:                       strings.TrimSpace(commandOptions.Name),
+		Description:                commandOptions.Description,
+		MainRelease:                commandOptions.MainRelease,
+		InstallationInProductionAt: installationDate.UnixNano() / int64(time.Millisecond),
+	}
+
+	//Call rest client
+	release := &Release{}
+	if err := cli.Client.DoRequest(http.MethodPost, "resources/./releases", &request, release); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	cli.Client.releases = nil
+	return release, nil
+}
+
+//ResolveRelease returns the name of an existing release given by name or alias ("latest", "next")
+func ResolveRelease(cli *Cli, name string) (string, error) {
+	releases, err := GetReleases(cli)
+	if err != nil {
+		return "", err
+	}
+	release, err := findRelease(releases, name, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return release.Name, nil
+}
+
+//findRelease returns the release with the name or alias ("latest", "next") of the releases sorted by installation date
+func findRelease(releases Releases, name string, now time.Time) (*Release, error) {
+	nowMillis := now.UnixNano() / int64(time.Millisecond)
+	switch name {
+	case ReleaseLatest:
+		for i := len(releases) - 1; i >= 0; i-- {
+			if releases[i].InstallationInProductionAt <= nowMillis {
+				return &releases[i], nil
+			}
+		}
+		return nil, fmt.Errorf("No release installed in production before %s", now.Format(DisplayDateTimeFormat))
+	case ReleaseNext:
+		for i := range releases {
+			if releases[i].InstallationInProductionAt > nowMillis {
+				return &releases[i], nil
+			}
+		}
+		return nil, fmt.Errorf("No release installed in production after %s", now.Format(DisplayDateTimeFormat))
+	}
+
+	for i := range releases {
+		if releases[i].Name == name {
+			return &releases[i], nil
+		}
+	}
+	//Suggest the releases with the same name ignoring case and the aliases
+	suggestions := []string{}
+	for _, release := range releases {
+		if strings.EqualFold(release.Name, name) {
+			suggestions = append(suggestions, release.Name)
+		}
+	}
+	suggestions = append(suggestions, ReleaseLatest, ReleaseNext)
+	return nil, fmt.Errorf("Unknown release %s, did you mean: %s", name, strings.Join(suggestions, ", "))
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindRelease(t *testing.T) {
+
+	// given
+	releases := Releases{
+		{Name: "RL-18.10", InstallationInProductionAt: time.Date(2018, 10, 15, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)},
+		{Name: "RL-19.04", InstallationInProductionAt: time.Date(2019, 4, 15, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)},
+		{Name: "RL-19.10", InstallationInProductionAt: time.Date(2019, 10, 14, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)},
+	}
+	now := time.Date(2019, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"RL-18.10", "RL-18.10"},
+		{ReleaseLatest, "RL-19.04"},
+		{ReleaseNext, "RL-19.10"},
+	}
+	for _, tt := range tests {
+		// when
+		release, err := findRelease(releases, tt.name, now)
+
+		// then
+		if err != nil {
+			t.Fatalf("Excepting no error: %s", err)
+		}
+		assertString(t, tt.want, release.Name, tt.name)
+	}
+}
+
+func TestFindReleaseUnknown(t *testing.T) {
+
+	// given
+	releases := Releases{{Name: "RL-19.04"}}
+
+	// when
+	_, err := findRelease(releases, "rl-19.04", time.Now())
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "did you mean: RL-19.04, latest, next") {
+		t.Fatalf("Expecting an error with suggestions, got %v", err)
+	}
+}
+
+func TestCreateDeploymentResolvesRelease(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := CreateDeployment(&cli, &CommandOptionsCreateDeployment{AppServer: "Test", AppName: []string{"testapp"}, AppVersion: []string{"1.0"}, Environment: "Y", Release: "RL-19.4"})
+
+	// then
+	if err == nil || !strings.HasPrefix(err.Error(), "Unknown release RL-19.4") {
+		t.Fatalf("Expecting an unknown release error, got %v", err)
+	}
+	if _, err := ResolveRelease(&cli, ReleaseLatest); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+//HTMLTable is a HTML page with a table with a header row, highlighted cells have the class "highlight"
+type HTMLTable struct {
+	Title  string
+	Header []string
+	Rows   [][]HTMLCell
+}
+
+//HTMLCell is a cell of a HTML table
+type HTMLCell struct {
+	Text      string
+	Highlight bool
+}
+
+//AddRow adds a row to the table
+func (table *HTMLTable) AddRow(cells ...HTMLCell) {
+	table.Rows = append(table.Rows, cells)
+}
+
+//Write writes the table as HTML page, line breaks in cells are kept
+func (table *HTMLTable) Write(w io.Writer) error {
+	var sb strings.Builder
+	title := html.EscapeString(table.Title)
+	fmt.Fprintf(&sb, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", title)
+	sb.WriteString("<style>\ntable { border-collapse: collapse; }\nth, td { border: 1px solid #999; padding: 4px 8px; text-align: left; }\ntd.highlight { background-color: #ffe08a; }\n</style>\n")
+	fmt.Fprintf(&sb, "</head>\n<body>\n<h2>%s</h2>\n<table>\n<tr>", title)
+	for _, cell := range table.Header {
+		fmt.Fprintf(&sb, "<th>%s</th>", htmlText(cell))
+	}
+	sb.WriteString("</tr>\n")
+	for _, row := range table.Rows {
+		sb.WriteString("<tr>")
+		for _, cell := range row {
+			if cell.Highlight {
+				fmt.Fprintf(&sb, "<td class=\"highlight\">%s</td>", htmlText(cell.Text))
+			} else {
+				fmt.Fprintf(&sb, "<td>%s</td>", htmlText(cell.Text))
+			}
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+//htmlText returns the escaped text of a cell with the line breaks as <br>
+func htmlText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
@@ -0,0 +1,71 @@
+/*
+Package report writes the results of liimactl commands as JUnit XML and Markdown reports for CI systems.
+*/
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+//TestSuites is the root element of a JUnit XML report
+type TestSuites struct {
+	XMLName    xml.Name    `xml:"testsuites"`
+	TestSuites []TestSuite `xml:"testsuite"`
+}
+
+//TestSuite is a JUnit test suite, example: all deployments of a promote
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Errors    int        `xml:"errors,attr"`
+	Time      float64    `xml:"time,attr"`
+	Timestamp string     `xml:"timestamp,attr,omitempty"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+//TestCase is a JUnit test case, example: the deployment of an app server
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Time      float64  `xml:"time,attr"`
+	Failure   *Message `xml:"failure,omitempty"`
+	Error     *Message `xml:"error,omitempty"`
+	Skipped   *Message `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+//Message is the failure, error or skipped message of a test case
+type Message struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+//AddTestCase adds a test case and counts its failure or error
+func (suite *TestSuite) AddTestCase(testCase TestCase) {
+	suite.TestCases = append(suite.TestCases, testCase)
+	suite.Tests++
+	suite.Time += testCase.Time
+	if testCase.Failure != nil {
+		suite.Failures++
+	}
+	if testCase.Error != nil {
+		suite.Errors++
+	}
+}
+
+//WriteJUnit writes the test suites as JUnit XML
+func WriteJUnit(w io.Writer, suites ...TestSuite) error {
+	data, err := xml.MarshalIndent(TestSuites{TestSuites: suites}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Couldn't write JUnit report: %v", err)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//MarkdownTable is a Markdown table with a header row
+type MarkdownTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+//AddRow adds a row to the table
+func (table *MarkdownTable) AddRow(cells ...string) {
+	table.Rows = append(table.Rows, cells)
+}
+
+//Write writes the table as Markdown
+func (table *MarkdownTable) Write(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, markdownRow(table.Header)); err != nil {
+		return err
+	}
+	separator := make([]string, len(table.Header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	if _, err := fmt.Fprintln(w, markdownRow(separator)); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		if _, err := fmt.Fprintln(w, markdownRow(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//markdownRow returns a table row, "|" and line breaks in cells are escaped
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		escaped[i] = strings.ReplaceAll(cell, "\n", "<br>")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteJUnit(t *testing.T) {
+
+	// given
+	suite := TestSuite{Name: "promote Y"}
+	suite.AddTestCase(TestCase{Name: "aps_bau", ClassName: "Y", Time: 1.5})
+	suite.AddTestCase(TestCase{Name: "vvn", ClassName: "Y", Time: 2, Failure: &Message{Message: "failed", Text: "deployment failed"}})
+	suite.AddTestCase(TestCase{Name: "kube", ClassName: "Y", Error: &Message{Message: "rejected"}})
+
+	// when
+	buf := new(bytes.Buffer)
+	if err := WriteJUnit(buf, suite); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// then
+	got := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuite name="promote Y" tests="3" failures="1" errors="1" time="3.5">`,
+		`<failure message="failed">deployment failed</failure>`,
+		`<error message="rejected"></error>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("JUnit report = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWriteMarkdownTable(t *testing.T) {
+
+	// given
+	table := MarkdownTable{Header: []string{"App server", "State"}}
+	table.AddRow("aps_bau", "success")
+	table.AddRow("a|b", "failed\nrejected")
+
+	// when
+	buf := new(bytes.Buffer)
+	if err := table.Write(buf); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// then
+	want := "| App server | State |\n| --- | --- |\n| aps_bau | success |\n| a\\|b | failed<br>rejected |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Markdown table = %v, want %v", got, want)
+	}
+}
+
+func TestWriteTextTable(t *testing.T) {
+
+	// given
+	table := TextTable{Header: []string{"App server", "B", "Y"}}
+	table.AddRow("aps_bau", "1.0", "1.1")
+	table.AddRow("vvn", "-", "2.0")
+
+	// when
+	buf := new(bytes.Buffer)
+	if err := table.Write(buf); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// then
+	want := "App server  B    Y\naps_bau     1.0  1.1\nvvn         -    2.0\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Text table = %v, want %v", got, want)
+	}
+}
+
+func TestWriteHTMLTable(t *testing.T) {
+
+	// given
+	table := HTMLTable{Title: "Versions <B>", Header: []string{"App server", "B"}}
+	table.AddRow(HTMLCell{Text: "aps_bau"}, HTMLCell{Text: "a 1.0\nb 2.0", Highlight: true})
+
+	// when
+	buf := new(bytes.Buffer)
+	if err := table.Write(buf); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// then
+	got := buf.String()
+	for _, want := range []string{
+		"<title>Versions &lt;B&gt;</title>",
+		"<tr><th>App server</th><th>B</th></tr>",
+		`<tr><td>aps_bau</td><td class="highlight">a 1.0<br>b 2.0</td></tr>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML table = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//TextTable is a plain text table with a header row, each column is as wide as its longest cell
+type TextTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+//AddRow adds a row to the table
+func (table *TextTable) AddRow(cells ...string) {
+	table.Rows = append(table.Rows, cells)
+}
+
+//Write writes the table with the columns separated by two spaces
+func (table *TextTable) Write(w io.Writer) error {
+	rows := append([][]string{table.Header}, table.Rows...)
+	widths := []int{}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	for _, row := range rows {
+		var sb strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				sb.WriteString(cell)
+			} else {
+				fmt.Fprintf(&sb, "%-*s  ", widths[i], cell)
+			}
+		}
+		if _, err := fmt.Fprintln(w, sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//Resource types of Liima
+const (
+	ResourceTypeApplicationServer = "APPLICATIONSERVER"
+	ResourceTypeApplication       = "APPLICATION"
+	ResourceTypeNode              = "NODE"
+	ResourceTypeRuntime           = "RUNTIME"
+)
+
+//Resources is a collection of Resource
+type Resources []Resource
+
+//Resource is a Liima resource (group) with its releases
+type Resource struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Releases []ResourceRelease `json:"releases"`
+}
+
+//ResourceRelease is a release of a resource
+type ResourceRelease struct {
+	ID      int    `json:"id"`
+	Release string `json:"release"`
+}
+
+//ResourceDetail is a release of a resource with its relations
+type ResourceDetail struct {
+	ID        int                `json:"id"`
+	Name      string             `json:"name"`
+	Type      string             `json:"type"`
+	Release   string             `json:"release"`
+	Relations []ResourceRelation `json:"relations"`
+}
+
+//ResourceRelation is a relation to another resource
+type ResourceRelation struct {
+	RelatedResourceName    string `json:"relatedResourceName"`
+	RelatedResourceRelease string `json:"relatedResourceRelease"`
+	Identifier             string `json:"identifier"`
+	Type                   string `json:"type"` //CONSUMED or PROVIDED
+}
+
+//ResourceDescription is a resource with the relations of a release and its hostnames
+type ResourceDescription struct {
+	Resource  Resource        `json:"resource"`
+	Release   *ResourceDetail `json:"release"`             //the described release
+	Hostnames Hostnames       `json:"hostnames,omitempty"` //only application servers
+}
+
+//sort.Interface
+func (slice Resources) Len() int {
+	return len(slice)
+}
+
+func (slice Resources) Less(i, j int) bool {
+	if slice[i].Type != slice[j].Type {
+		return slice[i].Type < slice[j].Type
+	}
+	return slice[i].Name < slice[j].Name
+}
+
+func (slice Resources) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+//HasRelease returns true if the resource has the release
+func (resource *Resource) HasRelease(release string) bool {
+	for _, r := range resource.Releases {
+		if r.Release == release {
+			return true
+		}
+	}
+	return false
+}
+
+//lastRelease returns the release of the resource with the latest installation date in production, empty if the resource has no release
+//The releases are sorted by installation date (see GetReleases), a resource without known releases returns its last release
+func (resource *Resource) lastRelease(releases Releases) string {
+	for i := len(releases) - 1; i >= 0; i-- {
+		if resource.HasRelease(releases[i].Name) {
+			return releases[i].Name
+		}
+	}
+	if len(resource.Releases) > 0 {
+		return resource.Releases[len(resource.Releases)-1].Release
+	}
+	return ""
+}
+
+//CommandOptionsGetResource used for the command options (flags)
+type CommandOptionsGetResource struct {
+	Type    string   `json:"type"`    //Resource type, all if empty
+	Name    []string `json:"name"`    //Name patterns (glob "aps_*" or regex "re:^aps_.*"), all if empty
+	Release string   `json:"release"` //Only resources with the release, all if empty
+}
+
+//CommandOptionsDescribeResource used for the command options (flags)
+type CommandOptionsDescribeResource struct {
+	Name    string `json:"name"`
+	Release string `json:"release"` //Described release, the last release of the resource if empty
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsGetResource) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	for _, err := range util.ValidatePatterns(commandOption.Name) {
+		errorList = append(errorList, err.Error())
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsDescribeResource) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	util.Check(&errorList, commandOption.Name != "", "want resource name")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetResources returns the resources filtered by type, name and release
+func GetResources(cli *Cli, commandOptions *CommandOptionsGetResource) (Resources, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+
+	//Build URL, the type is filtered by the server
+	url := "resources/./resources"
+	if commandOptions.Type != "" {
+		url += "?type=" + strings.ToUpper(commandOptions.Type)
+	}
+
+	//Call rest client
+	resources := Resources{}
+	if err := cli.Client.DoRequest(http.MethodGet, url, nil, &resources); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+
+	//Filter name and release
+	filtered := Resources{}
+	for _, resource := range resources {
+		if len(commandOptions.Name) > 0 {
+			if _, found := util.FindPattern(resource.Name, commandOptions.Name); !found {
+				continue
+			}
+		}
+		if commandOptions.Release != "" && !resource.HasRelease(commandOptions.Release) {
+			continue
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered, nil
+}
+
+//GetResource returns a resource with its releases
+func GetResource(cli *Cli, name string) (*Resource, error) {
+	resource := &Resource{}
+	if err := cli.Client.DoRequest(http.MethodGet, "resources/./resources/"+url.PathEscape(name), nil, resource); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	return resource, nil
+}
+
+//resourceRelease returns the release of the resource, the last release by installation date if empty
+func resourceRelease(cli *Cli, name string, release string) (string, error) {
+	resource, err := GetResource(cli, name)
+	if err != nil {
+		return "", err
+	}
+	if release == "" {
+		releases, err := GetReleases(cli)
+		if err != nil {
+			return "", err
+		}
+		release = resource.lastRelease(releases)
+	}
+	if !resource.HasRelease(release) {
+		return "", fmt.Errorf("Resource %s has no release %s", resource.Name, release)
+	}
+	return release, nil
+}
+
+//GetResourceDetail returns a release of a resource with its relations
+func GetResourceDetail(cli *Cli, name string, release string) (*ResourceDetail, error) {
+	detail := &ResourceDetail{}
+	if err := cli.Client.DoRequest(http.MethodGet, "resources/./resources/"+url.PathEscape(name)+"/"+url.PathEscape(release), nil, detail); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	return detail, nil
+}
+
+//DescribeResource returns a resource with the relations of a release and the hostnames of application servers
+func DescribeResource(cli *Cli, commandOptions *CommandOptionsDescribeResource) (*ResourceDescription, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+
+	resource, err := GetResource(cli, commandOptions.Name)
+	if err != nil {
+		return nil, err
+	}
+	description := &ResourceDescription{Resource: *resource}
+
+	release := commandOptions.Release
+	if release == "" {
+		releases, err := GetReleases(cli)
+		if err != nil {
+			return nil, err
+		}
+		release = resource.lastRelease(releases)
+	}
+	if release != "" {
+		if !resource.HasRelease(release) {
+			return nil, fmt.Errorf("Resource %s has no release %s", resource.Name, release)
+		}
+		if description.Release, err = GetResourceDetail(cli, resource.Name, release); err != nil {
+			return nil, err
+		}
+	}
+
+	if resource.Type == ResourceTypeApplicationServer {
+		if description.Hostnames, err = GetHostname(cli, &CommandOptionsHostName{AppServer: []string{resource.Name}}); err != nil {
+			return nil, err
+		}
+	}
+	return description, nil
+}
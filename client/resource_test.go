@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestDescribeResource(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	description, err := DescribeResource(&cli, &CommandOptionsDescribeResource{Name: "Test", Release: "RL-18.10"})
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	assertString(t, "RL-18.10", description.Release.Release, "release")
+	if len(description.Release.Relations) != 2 || len(description.Hostnames) != 1 {
+		t.Fatalf("Expecting two relations and one hostname, got %v", description)
+	}
+	assertString(t, "Test", description.Hostnames[0].AppServer, "hostname app server")
+}
+
+func TestDescribeResourceUnknownRelease(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := DescribeResource(&cli, &CommandOptionsDescribeResource{Name: "Test", Release: "RL-99.01"})
+
+	// then
+	if err == nil {
+		t.Fatalf("Excepting an error")
+	}
+	assertString(t, "Resource Test has no release RL-99.01", err.Error(), "error")
+}
+
+func TestGetResourcesInvalidPattern(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	_, err := GetResources(&cli, &CommandOptionsGetResource{Name: []string{"re:("}})
+
+	// then
+	if err == nil {
+		t.Fatalf("Excepting an error")
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+)
+
+//CommandOptionsRollbackDeployments used for the command options (flags)
+type CommandOptionsRollbackDeployments struct {
+	AppServer            []string `json:"appServerName"`
+	Environment          string   `json:"environmentName"`
+	AllAppServers        bool     //Rollback all app servers of the environment (bulk), exclusive blacklist
+	BlacklistAppServer   []string //Blacklist with all appServer patterns ("aps_*" or "re:^aps_.*"), which should not be rolled back
+	BlacklistRuntime     []string //Blacklist with all runtime patterns, which should not be rolled back
+	DeploymentDate       string   `json:"deploymentDate"`
+	TimeZone             string   //Timezone of the DeploymentDate, local timezone if empty
+	ExecuteShakedownTest bool     `json:"executeShakedownTest"`
+	Wait                 bool     //Wait as long the WaitTime until the deployment success or failed
+	MaxWaitTime          int      //Max wait time [seconds] until the deployment success or failed
+	PolicyOptions
+}
+
+//RollbackPlan is the rollback of an app server from the latest deployment to the previous successful deployment with different versions
+type RollbackPlan struct {
+	AppServerName string             `json:"appServerName"`
+	Latest        DeploymentResponse `json:"latest"`
+	Target        DeploymentResponse `json:"target"`
+	Changes       []VersionChange    `json:"changes"`
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsRollbackDeployments) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	util.Check(&errorList, len(commandOption.AppServer) > 0 || commandOption.AllAppServers, "want appServer or all app servers")
+	util.Check(&errorList, len(commandOption.AppServer) == 0 || !commandOption.AllAppServers, "want appServer or all app servers, not both")
+	if _, err := deploymentTime(commandOption.DeploymentDate, commandOption.TimeZone, time.Now()); err != nil {
+		errorList = append(errorList, err.Error())
+	}
+	for _, err := range util.ValidatePatterns(commandOption.BlacklistAppServer) {
+		errorList = append(errorList, fmt.Sprintf("BlacklistAppServer: %v", err))
+	}
+	for _, err := range util.ValidatePatterns(commandOption.BlacklistRuntime) {
+		errorList = append(errorList, fmt.Sprintf("BlacklistRuntime: %v", err))
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//PlanRollback returns for each app server the previous successful deployment with versions different from the latest deployment
+//App servers without such a deployment are skipped
+func PlanRollback(cli *Cli, commandOptions *CommandOptionsRollbackDeployments) ([]RollbackPlan, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	//Get the latest deployment of each app server in any state
+	latestDeployments, err := GetDeployment(cli, &CommandOptionsGetDeployment{
+		AppServer:   commandOptions.AppServer,
+		Environment: []string{commandOptions.Environment},
+		OnlyLatest:  true,
+		TrackingID:  -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plans := []RollbackPlan{}
+	for _, latest := range latestDeployments {
+		if commandOptions.blacklisted(&latest) {
+			continue
+		}
+
+		//Get all successful deployments of the app server
+		deployments, err := getAllDeployments(cli, &CommandOptionsGetDeployment{
+			AppServer:       []string{latest.AppServerName},
+			Environment:     []string{commandOptions.Environment},
+			DeploymentState: []DeploymentState{DeploymentStateSuccess},
+			TrackingID:      -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sortByDeploymentDate(deployments)
+
+		target, found := findRollbackTarget(&latest, deployments)
+		if !found {
+			log.Printf("No previous successful deployment with different versions found for app server: %s", latest.AppServerName)
+			continue
+		}
+		plans = append(plans, RollbackPlan{
+			AppServerName: latest.AppServerName,
+			Latest:        latest,
+			Target:        target,
+			Changes:       compareVersions(latest.versions(), target.versions()),
+		})
+	}
+	return plans, nil
+}
+
+//blacklisted returns true if the app server or the runtime of the deployment matches a blacklist pattern
+func (commandOption *CommandOptionsRollbackDeployments) blacklisted(deployment *DeploymentResponse) bool {
+	_, appServerFound := util.FindPattern(deployment.AppServerName, commandOption.BlacklistAppServer)
+	_, runtimeFound := util.FindPattern(deployment.RuntimeName, commandOption.BlacklistRuntime)
+	return appServerFound || runtimeFound
+}
+
+//findRollbackTarget returns the most recent successful deployment before the latest deployment with different versions
+func findRollbackTarget(latest *DeploymentResponse, chronologicalDeployments Deployments) (DeploymentResponse, bool) {
+	latestVersions := latest.versions()
+	for i := len(chronologicalDeployments) - 1; i >= 0; i-- {
+		deployment := chronologicalDeployments[i]
+		if deployment.ID == latest.ID || deployment.State != DeploymentStateSuccess || deployment.DeploymentDate > latest.DeploymentDate {
+			continue
+		}
+		if len(compareVersions(latestVersions, deployment.versions())) > 0 {
+			return deployment, true
+		}
+	}
+	return DeploymentResponse{}, false
+}
+
+//RollbackDeployments creates a deployment for each rollback plan with the release and versions of the target deployment
+func RollbackDeployments(cli *Cli, commandOptions *CommandOptionsRollbackDeployments, plans []RollbackPlan) (Deployments, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	//Check the policy once before creating deployments
+	deploymentDate, err := checkPolicyOnce(cli, "rollback", commandOptions.Environment, commandOptions.DeploymentDate, commandOptions.TimeZone, &commandOptions.PolicyOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	//Create deployments
+	createdDeployments := Deployments{}
+	for _, plan := range plans {
+		commandOptionsCreateDeployment := createOptionsFromDeployment(&plan.Target, commandOptions.Environment)
+		commandOptionsCreateDeployment.DeploymentDate = deploymentDate
+		commandOptionsCreateDeployment.ExecuteShakedownTest = commandOptions.ExecuteShakedownTest
+		commandOptionsCreateDeployment.policyChecked = true
+
+		deployment, err := CreateDeployment(cli, &commandOptionsCreateDeployment)
+		if err != nil {
+			return createdDeployments, fmt.Errorf("Error Create Deployment for app server: %s error: %s", plan.AppServerName, err)
+		}
+		createdDeployments = append(createdDeployments, *deployment)
+	}
+
+	//Wait on deployment success or failed
+	if commandOptions.Wait && len(createdDeployments) > 0 {
+		return waitForDeployments(cli, commandOptions.Environment, createdDeployments, commandOptions.MaxWaitTime)
+	}
+
+	return createdDeployments, nil
+}
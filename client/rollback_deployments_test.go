@@ -0,0 +1,93 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindRollbackTarget(t *testing.T) {
+
+	// given
+	deployments := Deployments{
+		newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0"),
+		newTestDeployment(2, 2000, DeploymentStateSuccess, "app1", "1.1"),
+		newTestDeployment(3, 3000, DeploymentStateSuccess, "app1", "1.2"),
+		newTestDeployment(4, 4000, DeploymentStateSuccess, "app1", "1.2"),
+	}
+	failed := newTestDeployment(5, 5000, DeploymentStateFailed, "app1", "1.3")
+
+	// when
+	afterRedeploy, foundAfterRedeploy := findRollbackTarget(&deployments[3], deployments)
+	afterFailure, foundAfterFailure := findRollbackTarget(&failed, deployments)
+	_, foundFirst := findRollbackTarget(&deployments[0], deployments)
+
+	// then
+	if !foundAfterRedeploy || afterRedeploy.ID != 2 {
+		t.Errorf("Expecting deployment 2 after a redeploy of the same versions, got %v", afterRedeploy.ID)
+	}
+	if !foundAfterFailure || afterFailure.ID != 4 {
+		t.Errorf("Expecting deployment 4 after a failed deployment, got %v", afterFailure.ID)
+	}
+	if foundFirst {
+		t.Errorf("Expecting no rollback target for the first deployment")
+	}
+}
+
+func TestRollbackBlacklisted(t *testing.T) {
+
+	// given
+	commandOptions := CommandOptionsRollbackDeployments{BlacklistAppServer: []string{"aps_*"}, BlacklistRuntime: []string{"re:^kube_.*"}}
+	deployment := newTestDeployment(1, 1000, DeploymentStateSuccess, "app", "1.0")
+
+	var tests = []struct {
+		name      string
+		appServer string
+		runtime   string
+		expected  bool
+	}{
+		{"app server pattern", "aps_bau", "jboss", true},
+		{"runtime regular expression", "vvn", "kube_helm", true},
+		{"not blacklisted", "vvn", "jboss", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// when
+			deployment.AppServerName, deployment.RuntimeName = tt.appServer, tt.runtime
+
+			// then
+			if got := commandOptions.blacklisted(&deployment); got != tt.expected {
+				t.Errorf("Expecting blacklisted %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRollbackDeployments(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	commandOptions := CommandOptionsRollbackDeployments{AppServer: []string{"Test"}, Environment: "T"}
+	plans := []RollbackPlan{{AppServerName: "Test", Target: newTestDeployment(1, 1000, DeploymentStateSuccess, "app1", "1.0")}}
+
+	// when
+	deployments, err := RollbackDeployments(&cli, &commandOptions, plans)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 1 || deployments[0].State != DeploymentStateSuccess {
+		t.Errorf("Expecting one successful deployment, got %v", deployments)
+	}
+
+	if _, err := PlanRollback(&cli, &CommandOptionsRollbackDeployments{Environment: "T"}); err == nil {
+		t.Errorf("Expecting an error without appServer")
+	}
+	if _, err := PlanRollback(&cli, &CommandOptionsRollbackDeployments{Environment: "T", AllAppServers: true, BlacklistRuntime: []string{"re:kube_("}}); err == nil || !strings.HasPrefix(err.Error(), "BlacklistRuntime: ") {
+		t.Errorf("Expecting an error on an invalid pattern, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client/util"
+	"gopkg.in/yaml.v3"
+)
+
+//Snapshot is the deployed state of an environment: the latest successful deployment of each app server
+type Snapshot struct {
+	Environment string              `yaml:"environment" json:"environment"`
+	CreatedAt   string              `yaml:"createdAt,omitempty" json:"createdAt,omitempty"`
+	AppServers  []SnapshotAppServer `yaml:"appServers" json:"appServers"`
+}
+
+//SnapshotAppServer is the deployed release, applications and deployment parameters of an app server
+type SnapshotAppServer struct {
+	Name                 string                        `yaml:"name" json:"name"`
+	Release              string                        `yaml:"release" json:"release"`
+	Runtime              string                        `yaml:"runtime,omitempty" json:"runtime,omitempty"`
+	Applications         []SnapshotApplication         `yaml:"applications" json:"applications"`
+	DeploymentParameters []SnapshotDeploymentParameter `yaml:"deploymentParameters,omitempty" json:"deploymentParameters,omitempty"`
+}
+
+//SnapshotApplication is an application with its version
+type SnapshotApplication struct {
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version" json:"version"`
+}
+
+//SnapshotDeploymentParameter is a deployment parameter
+type SnapshotDeploymentParameter struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+}
+
+//CommandOptionsSnapshot used for the command options (flags)
+type CommandOptionsSnapshot struct {
+	Environment string   `json:"environmentName"`
+	AppServer   []string `json:"appServerName"` //Only the given app servers, all if empty
+}
+
+//CommandOptionsApplySnapshot used for the command options (flags)
+type CommandOptionsApplySnapshot struct {
+	Environment          string   `json:"environmentName"` //Target environment, environment of the snapshot if empty
+	AppServer            []string `json:"appServerName"`   //Only the given app servers of the snapshot, all if empty
+	DeploymentDate       string   `json:"deploymentDate"`
+	TimeZone             string   //Timezone of the DeploymentDate, local timezone if empty
+	ExecuteShakedownTest bool     `json:"executeShakedownTest"`
+	Wait                 bool     //Wait as long the WaitTime until the deployment success or failed
+	MaxWaitTime          int      //Max wait time [seconds] until the deployment success or failed
+	PolicyOptions
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsSnapshot) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsApplySnapshot) validate(cli *Cli, snapshot *Snapshot) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	if commandOption.Environment != "" {
+		checkEnvironment(cli, &errorList, &commandOption.Environment, "environment")
+	} else {
+		checkEnvironment(cli, &errorList, &snapshot.Environment, "environment")
+	}
+	util.Check(&errorList, len(snapshot.AppServers) > 0, "want at least one appServer in the snapshot")
+	names := []string{}
+	for _, appServer := range snapshot.AppServers {
+		util.Check(&errorList, appServer.Name != "", "want name of each appServer in the snapshot")
+		util.Check(&errorList, len(appServer.Applications) > 0, "want applications of appServer %s in the snapshot", appServer.Name)
+		names = append(names, appServer.Name)
+	}
+	unknown := []string{}
+	for _, name := range commandOption.AppServer {
+		if !util.Contains(name, names) {
+			unknown = append(unknown, name)
+		}
+	}
+	util.Check(&errorList, len(unknown) == 0, "want appServer of the snapshot, unknown: %s", strings.Join(unknown, ", "))
+	if _, err := deploymentTime(commandOption.DeploymentDate, commandOption.TimeZone, time.Now()); err != nil {
+		errorList = append(errorList, err.Error())
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//targetEnvironment returns the environment to apply the snapshot on
+func (commandOption *CommandOptionsApplySnapshot) targetEnvironment(snapshot *Snapshot) string {
+	if commandOption.Environment != "" {
+		return commandOption.Environment
+	}
+	return snapshot.Environment
+}
+
+//CreateSnapshot returns the latest successful deployment of each app server on an environment as snapshot
+func CreateSnapshot(cli *Cli, commandOptions *CommandOptionsSnapshot) (*Snapshot, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+
+	deployments, err := getLatestSuccessfulDeployments(cli, commandOptions.Environment, commandOptions.AppServer)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(deployments)
+
+	snapshot := &Snapshot{
+		Environment: commandOptions.Environment,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+		AppServers:  []SnapshotAppServer{},
+	}
+	for _, deployment := range deployments {
+		snapshot.AppServers = append(snapshot.AppServers, newSnapshotAppServer(&deployment))
+	}
+	return snapshot, nil
+}
+
+//newSnapshotAppServer returns the snapshot of a deployment
+func newSnapshotAppServer(deployment *DeploymentResponse) SnapshotAppServer {
+	appServer := SnapshotAppServer{
+		Name:         deployment.AppServerName,
+		Release:      deployment.ReleaseName,
+		Runtime:      deployment.RuntimeName,
+		Applications: []SnapshotApplication{},
+	}
+	for _, app := range deployment.AppsWithVersion {
+		appServer.Applications = append(appServer.Applications, SnapshotApplication{Name: app.ApplicationName, Version: app.Version})
+	}
+	for _, parameter := range deployment.DeploymentParameters {
+		appServer.DeploymentParameters = append(appServer.DeploymentParameters, SnapshotDeploymentParameter{Key: parameter.Key, Value: parameter.Value})
+	}
+	return appServer
+}
+
+//WriteSnapshot writes the snapshot as YAML
+func WriteSnapshot(w io.Writer, snapshot *Snapshot) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("Couldn't write snapshot: %v", err)
+	}
+	return encoder.Close()
+}
+
+//ReadSnapshot reads a snapshot from YAML
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("Couldn't read snapshot: %v", err)
+	}
+	return snapshot, nil
+}
+
+//ReadSnapshots reads all snapshots of a YAML with multiple documents (separated by "---")
+func ReadSnapshots(r io.Reader) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	for {
+		snapshot := Snapshot{}
+		err := decoder.Decode(&snapshot)
+		if err == io.EOF {
+			return snapshots, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read snapshot: %v", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+}
+
+//ApplySnapshot creates a deployment for each app server of the snapshot with its release, versions and deployment parameters
+func ApplySnapshot(cli *Cli, commandOptions *CommandOptionsApplySnapshot, snapshot *Snapshot) (Deployments, error) {
+
+	if err := commandOptions.validate(cli, snapshot); err != nil {
+		return nil, err
+	}
+	environment := commandOptions.targetEnvironment(snapshot)
+
+	//Check the policy once before creating deployments
+	deploymentDate, err := checkPolicyOnce(cli, "apply snapshot of "+snapshot.Environment, environment, commandOptions.DeploymentDate, commandOptions.TimeZone, &commandOptions.PolicyOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	//Create deployments
+	createdDeployments := Deployments{}
+	for _, appServer := range snapshot.AppServers {
+		if len(commandOptions.AppServer) > 0 && !util.Contains(appServer.Name, commandOptions.AppServer) {
+			continue
+		}
+
+		commandOptionsCreateDeployment := appServer.createOptions(environment)
+		commandOptionsCreateDeployment.DeploymentDate = deploymentDate
+		commandOptionsCreateDeployment.ExecuteShakedownTest = commandOptions.ExecuteShakedownTest
+		commandOptionsCreateDeployment.policyChecked = true
+
+		deployment, err := CreateDeployment(cli, &commandOptionsCreateDeployment)
+		if err != nil {
+			return createdDeployments, fmt.Errorf("Error Create Deployment for app server: %s error: %s", appServer.Name, err)
+		}
+		createdDeployments = append(createdDeployments, *deployment)
+	}
+
+	//Wait on deployment success or failed
+	if commandOptions.Wait && len(createdDeployments) > 0 {
+		return waitForDeployments(cli, environment, createdDeployments, commandOptions.MaxWaitTime)
+	}
+
+	return createdDeployments, nil
+}
+
+//createOptions returns the command options to deploy the app server snapshot on the given environment
+func (appServer *SnapshotAppServer) createOptions(environment string) CommandOptionsCreateDeployment {
+	commandOptionsCreateDeployment := CommandOptionsCreateDeployment{}
+	commandOptionsCreateDeployment.AppServer = appServer.Name
+	commandOptionsCreateDeployment.Release = appServer.Release
+	commandOptionsCreateDeployment.Environment = environment
+	for _, app := range appServer.Applications {
+		commandOptionsCreateDeployment.AppName = append(commandOptionsCreateDeployment.AppName, app.Name)
+		commandOptionsCreateDeployment.AppVersion = append(commandOptionsCreateDeployment.AppVersion, app.Version)
+	}
+	for _, parameter := range appServer.DeploymentParameters {
+		commandOptionsCreateDeployment.Key = append(commandOptionsCreateDeployment.Key, parameter.Key)
+		commandOptionsCreateDeployment.Value = append(commandOptionsCreateDeployment.Value, parameter.Value)
+	}
+	return commandOptionsCreateDeployment
+}
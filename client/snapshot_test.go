@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+
+	// when
+	snapshot, err := CreateSnapshot(&cli, &CommandOptionsSnapshot{Environment: "P"})
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteSnapshot(buf, snapshot); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	read, err := ReadSnapshot(buf)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	assertString(t, "P", read.Environment, "environment")
+	if len(read.AppServers) != 1 || len(read.AppServers[0].Applications) != 1 {
+		t.Fatalf("Expecting one app server with one application, got %v", read.AppServers)
+	}
+	assertString(t, "Test", read.AppServers[0].Name, "name")
+	assertString(t, "testapp", read.AppServers[0].Applications[0].Name, "application name")
+	assertString(t, "1.0", read.AppServers[0].Applications[0].Version, "application version")
+}
+
+func TestApplySnapshot(t *testing.T) {
+
+	// given
+	config := Config{}
+	cli := Cli{}
+	cli.Client, _ = NewMockClient(&config)
+	snapshot, err := ReadSnapshot(strings.NewReader(`
+environment: P
+appServers:
+  - name: aps_bau
+    release: RL-19.04
+    applications:
+      - name: ch_mobi_aps_bau
+        version: 1.0.32
+    deploymentParameters:
+      - key: ForceRestart
+        value: "true"
+  - name: vvn
+    release: RL-19.04
+    applications:
+      - name: ch_mobi_vvn
+        version: 2.1.0
+`))
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+
+	// when
+	deployments, err := ApplySnapshot(&cli, &CommandOptionsApplySnapshot{Environment: "Y", AppServer: []string{"vvn"}}, snapshot)
+
+	// then
+	if err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if len(deployments) != 1 {
+		t.Errorf("Expecting one deployment, got %v", deployments)
+	}
+	createOptions := snapshot.AppServers[0].createOptions("Y")
+	assertString(t, "ForceRestart", createOptions.Key[0], "key")
+	assertString(t, "true", createOptions.Value[0], "value")
+
+	if _, err := ReadSnapshot(strings.NewReader("environment: P\nunknown: x\n")); err == nil {
+		t.Errorf("Expecting an error on an unknown field")
+	}
+	if _, err := ApplySnapshot(&cli, &CommandOptionsApplySnapshot{}, &Snapshot{Environment: "P"}); err == nil {
+		t.Errorf("Expecting an error on an empty snapshot")
+	}
+	if _, err := ApplySnapshot(&cli, &CommandOptionsApplySnapshot{AppServer: []string{"vvn", "aps_bau_typo", "kube"}}, snapshot); err == nil || err.Error() != "want appServer of the snapshot, unknown: aps_bau_typo, kube" {
+		t.Errorf("Expecting an error on unknown app servers, got %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client/util"
+	"gopkg.in/yaml.v3"
+)
+
+//TemplateManifest is the file of a template directory with the templates and their target paths and platforms
+const TemplateManifest = "templates.yaml"
+
+//Templates is a collection of Template
+type Templates []Template
+
+//Template is a template of a resource or resource type
+type Template struct {
+	ID              int      `json:"id" yaml:"-"`
+	Name            string   `json:"name" yaml:"name"`
+	TargetPath      string   `json:"targetPath" yaml:"targetPath"`
+	TargetPlatforms []string `json:"targetPlatforms" yaml:"targetPlatforms,omitempty"`
+	FileContent     string   `json:"fileContent" yaml:"-"`
+}
+
+//sort.Interface
+func (slice Templates) Len() int {
+	return len(slice)
+}
+
+func (slice Templates) Less(i, j int) bool {
+	return slice[i].Name < slice[j].Name
+}
+
+func (slice Templates) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+//findTemplate returns the template with the name
+func (slice Templates) findTemplate(name string) (*Template, bool) {
+	for i := range slice {
+		if slice[i].Name == name {
+			return &slice[i], true
+		}
+	}
+	return nil, false
+}
+
+//TemplateTarget identifies the templates of a release of a resource or of a resource type
+type TemplateTarget struct {
+	Resource     string `json:"resource"`
+	Release      string `json:"release"` //last release of the resource if empty
+	ResourceType string `json:"resourceType"`
+}
+
+//TemplateChange is the change of a template pushed from a directory
+type TemplateChange struct {
+	Template Template `json:"template"`
+	New      bool     `json:"new"`  //the template doesn't exist yet
+	Diff     string   `json:"diff"` //unified diff of the target path, platforms and content
+}
+
+//CommandOptionsPutTemplate used for the command options (flags)
+type CommandOptionsPutTemplate struct {
+	TemplateTarget
+	Name            string   `json:"name"`
+	File            string   `json:"file"`            //File with the content of the template
+	TargetPath      string   `json:"targetPath"`      //unchanged if empty, the name if empty for new templates
+	TargetPlatforms []string `json:"targetPlatforms"` //unchanged if empty
+}
+
+//CommandOptionsPushTemplate used for the command options (flags)
+type CommandOptionsPushTemplate struct {
+	TemplateTarget
+	Dir    string `json:"dir"`    //Directory written by pull
+	DryRun bool   `json:"dryRun"` //Only compute the changes, don't apply them
+}
+
+//Validate the given target
+func (target *TemplateTarget) validate(errorList *[]string) {
+	util.Check(errorList, (target.Resource != "") != (target.ResourceType != ""), "want either resource or resourceType")
+	util.Check(errorList, target.ResourceType == "" || target.Release == "", "want no release of a resourceType")
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsPutTemplate) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	commandOption.TemplateTarget.validate(&errorList)
+	util.Check(&errorList, commandOption.Name != "", "want template name")
+	util.Check(&errorList, commandOption.File != "", "want template file")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsPushTemplate) validate() error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	commandOption.TemplateTarget.validate(&errorList)
+	util.Check(&errorList, commandOption.Dir != "", "want template directory")
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//templatesURL resolves the release of the resource and returns the url of the templates
+func (target *TemplateTarget) templatesURL(cli *Cli) (string, error) {
+	var errorList []string
+	target.validate(&errorList)
+	if len(errorList) > 0 {
+		return "", errors.New(strings.Join(errorList, ", "))
+	}
+	if target.ResourceType != "" {
+		return "resources/./resourceTypes/" + url.PathEscape(strings.ToUpper(target.ResourceType)) + "/templates", nil
+	}
+
+	var err error
+	if target.Release, err = resourceRelease(cli, target.Resource, target.Release); err != nil {
+		return "", err
+	}
+	return "resources/./resources/" + url.PathEscape(target.Resource) + "/" + url.PathEscape(target.Release) + "/templates", nil
+}
+
+//GetTemplates returns the templates with their content sorted by name
+func GetTemplates(cli *Cli, target *TemplateTarget) (Templates, error) {
+	templatesURL, err := target.templatesURL(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	//Call rest client
+	templates := Templates{}
+	if err := cli.Client.DoRequest(http.MethodGet, templatesURL, nil, &templates); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	sort.Sort(templates)
+	return templates, nil
+}
+
+//GetTemplate returns a template with its content
+func GetTemplate(cli *Cli, target *TemplateTarget, name string) (*Template, error) {
+	templatesURL, err := target.templatesURL(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	//Call rest client
+	template := &Template{}
+	if err := cli.Client.DoRequest(http.MethodGet, templatesURL+"/"+url.PathEscape(name), nil, template); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	return template, nil
+}
+
+//PutTemplate creates or updates a template with the content of the file
+func PutTemplate(cli *Cli, commandOptions *CommandOptionsPutTemplate) (*Template, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(commandOptions.File)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read template file: %v", err)
+	}
+	templates, err := GetTemplates(cli, &commandOptions.TemplateTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &Template{Name: commandOptions.Name, TargetPath: commandOptions.Name}
+	if existing, found := templates.findTemplate(commandOptions.Name); found {
+		template = existing
+	}
+	template.FileContent = string(content)
+	if commandOptions.TargetPath != "" {
+		template.TargetPath = commandOptions.TargetPath
+	}
+	if len(commandOptions.TargetPlatforms) > 0 {
+		template.TargetPlatforms = commandOptions.TargetPlatforms
+	}
+	return putTemplate(cli, &commandOptions.TemplateTarget, template)
+}
+
+//putTemplate creates or updates a template
+func putTemplate(cli *Cli, target *TemplateTarget, template *Template) (*Template, error) {
+	templatesURL, err := target.templatesURL(cli)
+	if err != nil {
+		return nil, err
+	}
+
+	//Call rest client
+	response := &Template{}
+	if err := cli.Client.DoRequest(http.MethodPut, templatesURL+"/"+url.PathEscape(template.Name), template, response); err != nil {
+		return nil, fmt.Errorf("Error in rest call: %v", err)
+	}
+	return response, nil
+}
+
+//PullTemplates writes the templates to the directory, each template to a file with its name and their target paths and platforms to the manifest
+func PullTemplates(cli *Cli, target *TemplateTarget, dir string) (Templates, error) {
+	templates, err := GetTemplates(cli, target)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		name, err := templateFileName(dir, template.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return nil, fmt.Errorf("Couldn't create directory: %v", err)
+		}
+		if err := os.WriteFile(name, []byte(template.FileContent), 0644); err != nil {
+			return nil, fmt.Errorf("Couldn't write template file: %v", err)
+		}
+	}
+
+	manifest, err := yaml.Marshal(templates)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't write template manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, TemplateManifest), manifest, 0644); err != nil {
+		return nil, fmt.Errorf("Couldn't write template manifest: %v", err)
+	}
+	return templates, nil
+}
+
+//PushTemplates computes the changes of the templates of the directory against the server and applies them, if it is no dry run
+//Templates missing in the directory are not deleted. All changes are computed before the first change is applied,
+//on an error the applied changes until the error are returned
+func PushTemplates(cli *Cli, commandOptions *CommandOptionsPushTemplate) ([]TemplateChange, error) {
+
+	if err := commandOptions.validate(); err != nil {
+		return nil, err
+	}
+	local, err := readTemplateDir(commandOptions.Dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := GetTemplates(cli, &commandOptions.TemplateTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []TemplateChange{}
+	for _, template := range local {
+		change := TemplateChange{Template: template}
+		existing, found := remote.findTemplate(template.Name)
+		if !found {
+			change.New = true
+			existing = &Template{}
+		}
+		change.Template.ID = existing.ID
+		change.Diff = util.UnifiedDiff(template.Name+" (server)", template.Name+" (local)", templateText(existing), templateText(&template))
+		if change.Diff != "" {
+			changes = append(changes, change)
+		}
+	}
+	if commandOptions.DryRun {
+		return changes, nil
+	}
+	return ApplyTemplateChanges(cli, &commandOptions.TemplateTarget, changes)
+}
+
+//ApplyTemplateChanges applies the changes of the templates, for example computed by a dry run of PushTemplates
+//On an error the applied changes until the error are returned
+func ApplyTemplateChanges(cli *Cli, target *TemplateTarget, changes []TemplateChange) ([]TemplateChange, error) {
+	for i := range changes {
+		if _, err := putTemplate(cli, target, &changes[i].Template); err != nil {
+			return changes[:i], err
+		}
+	}
+	return changes, nil
+}
+
+//templateText returns the target path, platforms and content of a template to compare them
+func templateText(template *Template) string {
+	if template.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf("targetPath: %s\ntargetPlatforms: %s\n\n%s", template.TargetPath, strings.Join(template.TargetPlatforms, ", "), template.FileContent)
+}
+
+//readTemplateDir reads the templates of the manifest and their content of a directory written by PullTemplates
+func readTemplateDir(dir string) (Templates, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, TemplateManifest))
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read template manifest: %v", err)
+	}
+	templates := Templates{}
+	if err := yaml.Unmarshal(manifest, &templates); err != nil {
+		return nil, fmt.Errorf("Couldn't read template manifest: %v", err)
+	}
+
+	for i := range templates {
+		name, err := templateFileName(dir, templates[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't read template file: %v", err)
+		}
+		templates[i].FileContent = string(content)
+	}
+	return templates, nil
+}
+
+//templateFileName returns the file name of a template in the directory, names outside of the directory or of the manifest are rejected
+func templateFileName(dir string, name string) (string, error) {
+	fileName, err := util.JoinInDir(dir, name)
+	if err != nil {
+		return "", fmt.Errorf("Template %s: %v", name, err)
+	}
+	if fileName == filepath.Join(dir, TemplateManifest) {
+		return "", fmt.Errorf("Template %s has the name of the template manifest", name)
+	}
+	return fileName, nil
+}
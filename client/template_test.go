@@ -0,0 +1,32 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateFileName(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name     string //Name of the test
+		template string
+		want     string //Wanted testresult
+		wantErr  bool
+	}{
+		{"Test1", "server.xml", filepath.Join("templates", "server.xml"), false},
+		{"Test2", "../server.xml", "", true},
+		{"Test3", TemplateManifest, "", true},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := templateFileName("templates", tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("templateFileName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertString(t, tt.want, got, "file name")
+		})
+	}
+}
@@ -2,8 +2,8 @@ package util
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -127,8 +127,117 @@ func Check(errorList *[]string, isValid bool, errMsg string, args ...interface{}
 	}
 }
 
-//ValidateSingleChar validates a string if containing only a single char
-func ValidateSingleChar(input string) bool {
-	Re := regexp.MustCompile(`^[a-zA-Z]$`)
-	return Re.MatchString(input)
+//preReleaseRanks ranks the qualifiers of pre-releases, a snapshot is the development version before the first pre-release
+//Unknown qualifiers rank above the known pre-releases (unknownQualifierRank) and are compared as strings
+var preReleaseRanks = map[string]int{"snapshot": 1, "alpha": 2, "a": 2, "beta": 3, "b": 3, "milestone": 4, "m": 4, "rc": 5, "cr": 5}
+
+//unknownQualifierRank is the rank of the qualifiers not in preReleaseRanks
+const unknownQualifierRank = 6
+
+//releaseQualifiers mark a release, the version is equal to the version without the qualifier: 1.0.Final == 1.0
+var releaseQualifiers = []string{"ga", "final", "release"}
+
+//CompareVersions compares two versions by their parts separated by ".", "-" or "_"
+//Numeric parts are compared as numbers. A qualifier ("SNAPSHOT", "RC1") marks a pre-release, which is lower than
+//the version without the qualifier and lower than any numeric part: 1.0-RC1 < 1.0 < 1.0.1 and 1.0.1-SNAPSHOT < 1.0.1
+//The release qualifiers ("GA", "Final", "RELEASE") are ignored: 1.0.Final == 1.0
+//Returns -1 if a < b, 0 if a == b and 1 if a > b
+func CompareVersions(a string, b string) int {
+	split := func(r rune) bool { return r == '.' || r == '-' || r == '_' }
+	partsA := strings.FieldsFunc(a, split)
+	partsB := strings.FieldsFunc(b, split)
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		partA, partB := "", ""
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		if result := compareVersionParts(partA, partB); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+//compareVersionParts compares two parts of a version, an empty part or a release qualifier is missing
+//Ordered from low to high: qualifier, missing part, number
+func compareVersionParts(a string, b string) int {
+	kind := func(part string) int {
+		if part == "" || Contains(strings.ToLower(part), releaseQualifiers) {
+			return 1
+		}
+		if _, err := strconv.Atoi(part); err == nil {
+			return 2
+		}
+		return 0
+	}
+	kindA, kindB := kind(a), kind(b)
+	switch {
+	case kindA != kindB:
+		return compareInts(kindA, kindB)
+	case kindA == 2:
+		numberA, _ := strconv.Atoi(a)
+		numberB, _ := strconv.Atoi(b)
+		return compareInts(numberA, numberB)
+	case kindA == 1:
+		return 0
+	}
+
+	//Qualifiers by their rank and number ("RC2" < "RC10")
+	nameA, numberA := splitQualifier(a)
+	nameB, numberB := splitQualifier(b)
+	if result := compareInts(qualifierRank(nameA), qualifierRank(nameB)); result != 0 {
+		return result
+	}
+	if nameA != nameB {
+		return strings.Compare(nameA, nameB)
+	}
+	return compareInts(numberA, numberB)
+}
+
+//qualifierRank returns the rank of a qualifier name, unknownQualifierRank if it isn't a known pre-release
+func qualifierRank(name string) int {
+	if rank, found := preReleaseRanks[name]; found {
+		return rank
+	}
+	return unknownQualifierRank
+}
+
+//splitQualifier splits a qualifier into its lower case name and trailing number: "RC2" -> "rc", 2
+func splitQualifier(qualifier string) (string, int) {
+	qualifier = strings.ToLower(qualifier)
+	name := strings.TrimRight(qualifier, "0123456789")
+	number, _ := strconv.Atoi(qualifier[len(name):])
+	return name, number
+}
+
+//compareInts returns -1 if a < b, 0 if a == b and 1 if a > b
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+//VersionOrNone returns "none" for a missing version or release, used to print version changes
+func VersionOrNone(version string) string {
+	if version == "" {
+		return "none"
+	}
+	return version
+}
+
+//JoinInDir joins a relative path (with "/" separators) to a directory, paths outside of the directory are rejected
+func JoinInDir(dir string, relative string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(relative))
+	if filepath.IsAbs(cleaned) || strings.HasPrefix(relative, "/") || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside of the directory %s", relative, dir)
+	}
+	return filepath.Join(dir, cleaned), nil
 }
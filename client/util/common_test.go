@@ -107,3 +107,42 @@ func TestSetValueIfTagExists(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareVersions(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string //Name of the test
+		a    string //Argument
+		b    string //Argument
+		want int    //Wanted testresult
+	}{
+		{"Equal", "1.0.1", "1.0.1", 0},
+		{"Patch", "1.0.1", "1.0.2", -1},
+		{"Numeric", "1.0.10", "1.0.9", 1},
+		{"Longer", "1.0", "1.0.1", -1},
+		{"Snapshot", "1.0.1-SNAPSHOT", "1.0.1", -1},
+		{"Release candidate", "1.0.1", "1.0.1-RC1", 1},
+		{"Snapshot before release candidate", "1.0.1-SNAPSHOT", "1.0.1-RC1", -1},
+		{"Release candidate number", "1.0.1-RC10", "1.0.1-RC2", 1},
+		{"Qualifier case", "1.0.1-rc1", "1.0.1-RC1", 0},
+		{"Pre-release before patch", "1.0-RC1", "1.0.1", -1},
+		{"Next snapshot", "1.0.2-SNAPSHOT", "1.0.1", 1},
+		{"Separators", "1_2", "1.2", 0},
+		{"Final", "1.0.Final", "1.0", 0},
+		{"GA", "1.0.1-GA", "1.0.1-SNAPSHOT", 1},
+		{"Release before patch", "1.0.RELEASE", "1.0.1", -1},
+		{"Unknown qualifier after release candidate", "1.0-hotfix", "1.0-RC1", 1},
+		{"Unknown qualifier before release", "1.0-hotfix", "1.0", -1},
+		{"Unknown qualifiers", "1.0-beer", "1.0-hotfix", -1},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareVersions(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	//Embedded timezone database, the zoneinfo files are missing on windows
+	_ "time/tzdata"
+)
+
+//DayFormat defines the format of a single day
+const DayFormat = "2006-01-02"
+
+//wallClockFormats are the accepted absolute formats without timezone, interpreted in the given location
+var wallClockFormats = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006.01.02 15:04",
+	"02.01.2006 15:04",
+	DayFormat,
+}
+
+//zonedFormats are the accepted absolute formats with an explicit timezone offset (ISO-8601)
+var zonedFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05-0700",
+}
+
+//ParseDuration parses a duration like time.ParseDuration and supports additionally days, example: "7d", "1d12h"
+func ParseDuration(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	var days time.Duration
+	if i := strings.Index(input, "d"); i > 0 {
+		nbr, err := strconv.Atoi(input[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", input)
+		}
+		days = time.Duration(nbr) * 24 * time.Hour
+		input = input[i+1:]
+		if input == "" {
+			return days, nil
+		}
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", input)
+	}
+	return days + duration, nil
+}
+
+//ParsePointInTime parses a point in time given either as duration before now ("24h", "7d") or as date ("2006-01-02", "2006-01-02 15:04") in the location of now
+func ParsePointInTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if duration, err := ParseDuration(input); err == nil {
+		return now.Add(-duration), nil
+	}
+	if t, err := parseAbsolute(input, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid point in time %q, want a duration (24h, 7d) or a date (YYYY-MM-DD [hh:mm])", input)
+}
+
+//ParseDateTime parses a date time in the location of now. Accepted are
+// - ISO-8601 with or without timezone offset: "2026-10-17T06:00:00+02:00", "2026-10-17T06:00"
+// - "YYYY-MM-DD hh:mm", "YYYY.MM.DD hh:mm", "DD.MM.YYYY hh:mm" and "YYYY-MM-DD"
+// - relative to now: "now", "+2h", "+1d6h"
+// - relative days: "today 18:00", "tomorrow 06:00"
+//A wall clock time skipped by daylight saving time is an error, a repeated wall clock time is the earlier one
+func ParseDateTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	lower := strings.ToLower(input)
+
+	switch {
+	case lower == "now":
+		return now, nil
+
+	case strings.HasPrefix(lower, "+"):
+		duration, err := ParseDuration(lower[1:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(duration), nil
+
+	case strings.HasPrefix(lower, "today"), strings.HasPrefix(lower, "tomorrow"):
+		day := now
+		clock := strings.TrimPrefix(lower, "today")
+		if strings.HasPrefix(lower, "tomorrow") {
+			day = now.AddDate(0, 0, 1)
+			clock = strings.TrimPrefix(lower, "tomorrow")
+		}
+		clock = strings.TrimSpace(clock)
+		if clock == "" {
+			clock = "00:00"
+		}
+		c, err := time.Parse("15:04", clock)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q in %q, want hh:mm", clock, input)
+		}
+		return wallClockIn(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), 0, now.Location())
+	}
+
+	t, err := parseAbsolute(input, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, want ISO-8601, 'YYYY-MM-DD hh:mm', 'DD.MM.YYYY hh:mm', '+2h' or 'tomorrow 06:00': %v", input, err)
+	}
+	return t, nil
+}
+
+//LoadLocation returns the location with the given IANA name ("Europe/Zurich"), the local timezone if the name is empty
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %v", name, err)
+	}
+	return loc, nil
+}
+
+//ParseDay parses a day ("2006-01-02") in the given location and returns its begin and the begin of the next day (exclusive end)
+func ParseDay(input string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation(DayFormat, strings.TrimSpace(input), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid day %q, want YYYY-MM-DD", input)
+	}
+	//AddDate instead of 24h, a day has not always 24h (daylight saving time)
+	return day, day.AddDate(0, 0, 1), nil
+}
+
+//parseAbsolute parses an absolute date time, formats without timezone are interpreted in the given location
+func parseAbsolute(input string, loc *time.Location) (time.Time, error) {
+	for _, format := range zonedFormats {
+		if t, err := time.Parse(format, input); err == nil {
+			return t, nil
+		}
+	}
+	for _, format := range wallClockFormats {
+		//Parse in UTC to get the wall clock, the location is applied afterwards
+		if t, err := time.Parse(format, input); err == nil {
+			return wallClockIn(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), loc)
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown format")
+}
+
+//wallClockIn returns the time of a wall clock in the given location
+//An error is returned, if the wall clock doesn't exist (begin of daylight saving time)
+//If the wall clock exists twice (end of daylight saving time), the earlier time is returned
+func wallClockIn(year int, month time.Month, day, hour, min, sec int, loc *time.Location) (time.Time, error) {
+	t := time.Date(year, month, day, hour, min, sec, 0, loc)
+	if t.Day() != day || t.Hour() != hour || t.Minute() != min {
+		return time.Time{}, fmt.Errorf("%04d-%02d-%02d %02d:%02d doesn't exist in timezone %s (daylight saving time)", year, month, day, hour, min, loc)
+	}
+	if earlier := t.Add(-time.Hour); earlier.Hour() == hour && earlier.Minute() == min {
+		return earlier, nil
+	}
+	return t, nil
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePointInTime(t *testing.T) {
+
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, zurich)
+
+	//Tests
+	tests := []struct {
+		name    string    //Name of the test
+		input   string    //Argument
+		want    time.Time //Wanted testresult
+		wantErr bool      //Error expected
+	}{
+		{"Hours", "24h", time.Date(2026, 10, 17, 12, 30, 0, 0, zurich), false},
+		{"Days", "7d", time.Date(2026, 10, 11, 12, 30, 0, 0, zurich), false},
+		{"DaysAndHours", "1d2h", time.Date(2026, 10, 17, 10, 30, 0, 0, zurich), false},
+		{"Day", "2026-10-01", time.Date(2026, 10, 1, 0, 0, 0, 0, zurich), false},
+		{"DayAndTime", "2026-10-01 08:15", time.Date(2026, 10, 1, 8, 15, 0, 0, zurich), false},
+		{"Invalid", "yesterday", time.Time{}, true},
+		{"InvalidDays", "xd", time.Time{}, true},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePointInTime(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePointInTime(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParsePointInTime(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDay(t *testing.T) {
+
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Day of the switch from summer to winter time has 25 hours
+	from, to, err := ParseDay("2026-10-25", zurich)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 25, 0, 0, 0, 0, zurich); !from.Equal(want) {
+		t.Errorf("ParseDay() from = %v, want %v", from, want)
+	}
+	if want := time.Date(2026, 10, 26, 0, 0, 0, 0, zurich); !to.Equal(want) {
+		t.Errorf("ParseDay() to = %v, want %v", to, want)
+	}
+
+	if _, _, err := ParseDay("25.10.2026", zurich); err == nil {
+		t.Errorf("ParseDay() want error on invalid format")
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 24, 12, 30, 0, 0, zurich)
+
+	//Tests
+	tests := []struct {
+		name    string    //Name of the test
+		input   string    //Argument
+		want    time.Time //Wanted testresult
+		wantErr bool      //Error expected
+	}{
+		{"Now", "now", now, false},
+		{"Relative", "+2h", time.Date(2026, 10, 24, 14, 30, 0, 0, zurich), false},
+		{"RelativeDays", "+1d6h", time.Date(2026, 10, 25, 17, 30, 0, 0, zurich), false},
+		{"Today", "today 18:00", time.Date(2026, 10, 24, 18, 0, 0, 0, zurich), false},
+		{"Tomorrow", "tomorrow 06:00", time.Date(2026, 10, 25, 6, 0, 0, 0, zurich), false},
+		{"TomorrowMidnight", "Tomorrow", time.Date(2026, 10, 25, 0, 0, 0, 0, zurich), false},
+		{"ISO", "2026-10-17T06:00", time.Date(2026, 10, 17, 6, 0, 0, 0, zurich), false},
+		{"ISOWithOffset", "2026-10-17T06:00:00Z", time.Date(2026, 10, 17, 8, 0, 0, 0, zurich), false},
+		{"Dashes", "2018-02-01 17:00", time.Date(2018, 2, 1, 17, 0, 0, 0, zurich), false},
+		{"Dots", "2018.02.01 17:00", time.Date(2018, 2, 1, 17, 0, 0, 0, zurich), false},
+		{"Swiss", "01.02.2018 17:00", time.Date(2018, 2, 1, 17, 0, 0, 0, zurich), false},
+		{"WinterTime", "2026-10-25 03:30", time.Date(2026, 10, 25, 2, 30, 0, 0, time.UTC), false},
+		{"SummerTime", "2026-03-29 03:30", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), false},
+		{"SkippedByDST", "2026-03-29 02:30", time.Time{}, true},
+		{"RepeatedByDST", "2026-10-25 02:30", time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), false},
+		{"Typo", "2018-02-31 17:00", time.Time{}, true},
+		{"Garbage", "next friday", time.Time{}, true},
+		{"InvalidClock", "tomorrow 25:00", time.Time{}, true},
+		{"InvalidRelative", "+2x", time.Time{}, true},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateTime(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateTime(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDateTime(%v) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	if loc, err := LoadLocation(""); err != nil || loc != time.Local {
+		t.Errorf("LoadLocation(\"\") = %v, %v, want local timezone", loc, err)
+	}
+	if _, err := LoadLocation("Europe/Zurich"); err != nil {
+		t.Errorf("LoadLocation(Europe/Zurich) failed with %v", err)
+	}
+	if _, err := LoadLocation("Mars/Olympus"); err == nil {
+		t.Errorf("LoadLocation(Mars/Olympus) want error")
+	}
+}
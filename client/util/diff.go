@@ -0,0 +1,249 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+//DiffContextLines is the number of unchanged lines around the changes of an unified diff
+const DiffContextLines = 3
+
+//diffLine is a line of a diff, kind is ' ' (unchanged), '-' (removed) or '+' (added)
+type diffLine struct {
+	kind byte
+	text string
+	from int //lines of from before this line
+	to   int //lines of to before this line
+}
+
+//UnifiedDiff returns the unified diff of two texts with their names, an empty string if the texts are equal
+func UnifiedDiff(fromName string, toName string, from string, to string) string {
+	if from == to {
+		return ""
+	}
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		//Join the changes with at most two times the context lines between them
+		start := i - DiffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(lines) {
+			if lines[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].kind == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*DiffContextLines {
+				end += DiffContextLines
+				if end > len(lines) {
+					end = len(lines)
+				}
+				break
+			}
+			end = next
+		}
+		writeHunk(&sb, lines[start:end])
+		i = end
+	}
+	return sb.String()
+}
+
+//writeHunk writes a hunk with its header
+func writeHunk(sb *strings.Builder, lines []diffLine) {
+	fromCount, toCount := 0, 0
+	for _, line := range lines {
+		if line.kind != '+' {
+			fromCount++
+		}
+		if line.kind != '-' {
+			toCount++
+		}
+	}
+	fromStart, toStart := lines[0].from, lines[0].to
+	if fromCount > 0 {
+		fromStart++
+	}
+	if toCount > 0 {
+		toStart++
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(fromStart, fromCount), hunkRange(toStart, toCount))
+	for _, line := range lines {
+		sb.WriteByte(line.kind)
+		sb.WriteString(line.text)
+		sb.WriteByte('\n')
+	}
+}
+
+//hunkRange returns the range of a hunk header, the count is omitted if it is one (like diff -u)
+func hunkRange(start int, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+//splitLines splits a text into lines, without the empty line after a trailing newline
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+//diffLines returns the lines of the diff with the shortest edit script of the lines
+func diffLines(from []string, to []string) []diffLine {
+	d := differ{a: from, b: to, removed: make([]bool, len(from)), added: make([]bool, len(to))}
+	d.compare(0, len(from), 0, len(to))
+
+	lines := []diffLine{}
+	fromLine, toLine := 0, 0
+	add := func(kind byte, text string) {
+		lines = append(lines, diffLine{kind: kind, text: text, from: fromLine, to: toLine})
+		if kind != '+' {
+			fromLine++
+		}
+		if kind != '-' {
+			toLine++
+		}
+	}
+	for fromLine < len(from) || toLine < len(to) {
+		switch {
+		case fromLine < len(from) && d.removed[fromLine]:
+			add('-', from[fromLine])
+		case toLine < len(to) && d.added[toLine]:
+			add('+', to[toLine])
+		default:
+			add(' ', from[fromLine])
+		}
+	}
+	return lines
+}
+
+//differ computes the shortest edit script of two line slices with the linear space variant of the Myers algorithm
+//("An O(ND) Difference Algorithm and Its Variations"), the memory is linear in the number of lines
+type differ struct {
+	a, b    []string
+	removed []bool //removed lines of a
+	added   []bool //added lines of b
+}
+
+//compare marks the removed and added lines of a[aLo:aHi] and b[bLo:bHi]
+func (d *differ) compare(aLo int, aHi int, bLo int, bHi int) {
+
+	//The common prefix and suffix are unchanged
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		aLo++
+		bLo++
+	}
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	if aLo == aHi || bLo == bHi {
+		d.change(aLo, aHi, bLo, bHi)
+		return
+	}
+	x, y, found := d.middleSnake(aLo, aHi, bLo, bHi)
+	if !found {
+		d.change(aLo, aHi, bLo, bHi)
+		return
+	}
+	//Split at the middle of the shortest edit script
+	d.compare(aLo, x, bLo, y)
+	d.compare(x, aHi, y, bHi)
+}
+
+//change marks all lines of a[aLo:aHi] as removed and all lines of b[bLo:bHi] as added
+func (d *differ) change(aLo int, aHi int, bLo int, bHi int) {
+	for i := aLo; i < aHi; i++ {
+		d.removed[i] = true
+	}
+	for j := bLo; j < bHi; j++ {
+		d.added[j] = true
+	}
+}
+
+//middleSnake searches the shortest edit script from the begin and the end of the ranges at the same time
+//and returns the point where both searches overlap, not found if the ranges have no line in common
+func (d *differ) middleSnake(aLo int, aHi int, bLo int, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+
+	//forward[offset+k] and backward[offset+k] are the furthest x on diagonal k (x-y) from the begin and from the end
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	//With an odd delta the searches overlap on a forward step, otherwise on a backward step
+	odd := delta%2 != 0
+	//Diagonals leaving the ranges are not searched anymore
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+	for step := 0; step < maxD; step++ {
+		for k := -step + forwardStart; k <= step-forwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if x > n {
+				forwardEnd += 2
+			} else if y > m {
+				forwardStart += 2
+			} else if odd {
+				if i := offset + delta - k; i >= 0 && i < len(backward) && backward[i] != -1 && x >= n-backward[i] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+		for k := -step + backwardStart; k <= step-backwardEnd; k += 2 {
+			var x int
+			if k == -step || (k != step && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			if x > n {
+				backwardEnd += 2
+			} else if y > m {
+				backwardStart += 2
+			} else if !odd {
+				if i := offset + delta - k; i >= 0 && i < len(forward) && forward[i] != -1 && forward[i] >= n-x {
+					return aLo + forward[i], bLo + forward[i] - (delta - k), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
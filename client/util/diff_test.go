@@ -0,0 +1,56 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string //Name of the test
+		from string
+		to   string
+		want string //Wanted testresult
+	}{
+		{"Test1", "a\nb\n", "a\nb\n", ""},
+		{"Test2", "a\nb\nc\n", "a\nx\nc\n", "--- T\n+++ P\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n"},
+		{"Test3", "", "a\n", "--- T\n+++ P\n@@ -0,0 +1 @@\n+a\n"},
+		{"Test4", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", "1\nx\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			"--- T\n+++ P\n@@ -1,5 +1,5 @@\n 1\n-2\n+x\n 3\n 4\n 5\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n"},
+		{"Test5", "1\n2\n3\n4\n5\n6\n7\n8\n", "1\nx\n3\n4\n5\n6\n7\ny\n",
+			"--- T\n+++ P\n@@ -1,8 +1,8 @@\n 1\n-2\n+x\n 3\n 4\n 5\n 6\n 7\n-8\n+y\n"},
+		{"Test6", "a\nb\n", "b\n", "--- T\n+++ P\n@@ -1,2 +1 @@\n-a\n b\n"},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("T", "P", tt.from, tt.to); got != tt.want {
+				t.Errorf("UnifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+//Tests the diff of large texts, the memory of the diff is linear in the number of lines
+func TestUnifiedDiffLargeTexts(t *testing.T) {
+
+	//Every 50th line of 50000 lines is changed
+	var from, to strings.Builder
+	for i := 0; i < 50000; i++ {
+		line := strconv.Itoa(i)
+		from.WriteString(line + "\n")
+		if i%50 == 0 {
+			line = "changed " + line
+		}
+		to.WriteString(line + "\n")
+	}
+
+	got := UnifiedDiff("T", "P", from.String(), to.String())
+	if removed, added := strings.Count(got, "\n-"), strings.Count(got, "\n+")-1; removed != 1000 || added != 1000 {
+		t.Errorf("Expecting 1000 removed and added lines, got %d removed and %d added", removed, added)
+	}
+}
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+//RegexPatternPrefix marks a pattern as regular expression, example: "re:^kube_.*"
+const RegexPatternPrefix = "re:"
+
+//PatternFilePrefix marks a pattern as file with one pattern per line, example: "@servers.txt"
+const PatternFilePrefix = "@"
+
+//MatchPattern tests if a value matches a pattern, spaces will be trimed
+//Patterns are regular expressions with the prefix "re:" or glob patterns ("aps_*"), a pattern without wildcards matches only the same value
+func MatchPattern(str string, pattern string) bool {
+	str = strings.TrimSpace(str)
+	pattern = strings.TrimSpace(pattern)
+	if strings.HasPrefix(pattern, RegexPatternPrefix) {
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, RegexPatternPrefix))
+		return err == nil && re.MatchString(str)
+	}
+	matched, err := path.Match(pattern, str)
+	return err == nil && matched
+}
+
+//FindPattern returns the first pattern of the list matching the value and true, or false if none matches
+func FindPattern(str string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		if MatchPattern(str, pattern) {
+			return strings.TrimSpace(pattern), true
+		}
+	}
+	return "", false
+}
+
+//IsLiteralPattern tests if a pattern matches only the same value (no regular expression, no wildcards)
+func IsLiteralPattern(pattern string) bool {
+	return !strings.HasPrefix(strings.TrimSpace(pattern), RegexPatternPrefix) && !strings.ContainsAny(pattern, `*?[\`)
+}
+
+//ValidatePatterns returns an error for each invalid regular expression or glob pattern
+func ValidatePatterns(patterns []string) []error {
+	errs := []error{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		var err error
+		if strings.HasPrefix(pattern, RegexPatternPrefix) {
+			_, err = regexp.Compile(strings.TrimPrefix(pattern, RegexPatternPrefix))
+		} else {
+			_, err = path.Match(pattern, "")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern %q: %v", pattern, err))
+		}
+	}
+	return errs
+}
+
+//ExpandPatternFiles replaces each pattern "@file" by the patterns of the file, one per line
+//Empty lines and lines starting with "#" are ignored
+func ExpandPatternFiles(patterns []string) ([]string, error) {
+	expanded := []string{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if !strings.HasPrefix(pattern, PatternFilePrefix) {
+			expanded = append(expanded, pattern)
+			continue
+		}
+		filePatterns, err := readPatternFile(strings.TrimPrefix(pattern, PatternFilePrefix))
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, filePatterns...)
+	}
+	return expanded, nil
+}
+
+//readPatternFile reads the patterns of a file, one per line
+func readPatternFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't read filter file: %v", err)
+	}
+	defer file.Close()
+
+	patterns := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Couldn't read filter file %s: %v", name, err)
+	}
+	return patterns, nil
+}
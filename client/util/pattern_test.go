@@ -0,0 +1,61 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchPattern(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name    string //Name of the test
+		str     string
+		pattern string
+		want    bool //Wanted testresult
+	}{
+		{"Test1", "aps_bau", "aps_bau", true},
+		{"Test2", " aps_bau", "aps_bau ", true},
+		{"Test3", "aps_bau", "aps_*", true},
+		{"Test4", "kube_aps", "aps_*", false},
+		{"Test5", "kube_aps", "re:^kube_.*", true},
+		{"Test6", "aps_kube", "re:^kube_.*", false},
+		{"Test7", "aps_bau", "re:[", false},
+		{"Test8", "aps_bau", "aps_ba?", true},
+	}
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchPattern(tt.str, tt.pattern); got != tt.want {
+				t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.str, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if errs := ValidatePatterns([]string{"aps_*", "re:^kube_.*", "re:[", "aps_["}); len(errs) != 2 {
+		t.Errorf("ValidatePatterns() = %v, want 2 errors", errs)
+	}
+}
+
+func TestExpandPatternFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "servers.txt")
+	if err := os.WriteFile(file, []byte("# servers\naps_*\n\n re:^kube_.* \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ExpandPatternFiles([]string{"first", "@" + file})
+	if err != nil {
+		t.Fatalf("ExpandPatternFiles() failed with %v", err)
+	}
+	if want := []string{"first", "aps_*", "re:^kube_.*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandPatternFiles() = %v, want %v", got, want)
+	}
+	if _, err := ExpandPatternFiles([]string{"@" + file + ".missing"}); err == nil {
+		t.Errorf("ExpandPatternFiles() of a missing file want error")
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//CommandOptionsVersionMatrix used for the command options (flags)
+type CommandOptionsVersionMatrix struct {
+	Environment []string `json:"environmentName"` //Columns in the given order, all environments in the order of the server if empty
+	AppServer   []string `json:"appServerName"`   //Only the given app servers, all if empty
+}
+
+//VersionMatrix is the latest successful deployment of each app server (rows) on each environment (columns)
+type VersionMatrix struct {
+	Environments []string           `json:"environments"`
+	AppServers   []VersionMatrixRow `json:"appServers"` //sorted by name
+}
+
+//VersionMatrixRow is an app server with a cell for each environment of the matrix
+type VersionMatrixRow struct {
+	AppServerName string              `json:"appServerName"`
+	Cells         []VersionMatrixCell `json:"cells"`
+}
+
+//VersionMatrixCell is the latest successful deployment of an app server on an environment
+type VersionMatrixCell struct {
+	Environment string            `json:"environment"`
+	Deployed    bool              `json:"deployed"`
+	Release     string            `json:"release"`
+	Versions    map[string]string `json:"versions"` //version by application name
+	Changed     bool              `json:"changed"`  //the versions differ from the previous environment
+}
+
+//VersionsText returns the applications with their versions sorted by name and separated by the separator, "-" if not deployed
+func (cell *VersionMatrixCell) VersionsText(separator string) string {
+	if !cell.Deployed {
+		return "-"
+	}
+	versions := []string{}
+	for app, version := range cell.Versions {
+		versions = append(versions, app+" "+version)
+	}
+	sort.Strings(versions)
+	return strings.Join(versions, separator)
+}
+
+//Validate the given command options
+func (commandOption *CommandOptionsVersionMatrix) validate(cli *Cli) error {
+
+	//Errorlist
+	var errorList []string
+	//Checks and add to errorList if an error
+	for i := range commandOption.Environment {
+		checkEnvironment(cli, &errorList, &commandOption.Environment[i], "environment")
+	}
+
+	//Return all errors as one
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, ", "))
+	}
+	return nil
+}
+
+//GetVersionMatrix returns the latest successful deployment of the app servers on the environments
+func GetVersionMatrix(cli *Cli, commandOptions *CommandOptionsVersionMatrix) (*VersionMatrix, error) {
+
+	if err := commandOptions.validate(cli); err != nil {
+		return nil, err
+	}
+	environments := commandOptions.Environment
+	if len(environments) == 0 {
+		all, err := GetEnvironments(cli)
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range all {
+			environments = append(environments, environment.Name)
+		}
+	}
+
+	deployments := []Deployments{}
+	for _, environment := range environments {
+		environmentDeployments, err := getLatestSuccessfulDeployments(cli, environment, commandOptions.AppServer)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't get deployments of environment %s: %v", environment, err)
+		}
+		deployments = append(deployments, environmentDeployments)
+	}
+	return newVersionMatrix(environments, deployments), nil
+}
+
+//newVersionMatrix returns the matrix of the deployments, deployments[i] are the deployments on environments[i]
+func newVersionMatrix(environments []string, deployments []Deployments) *VersionMatrix {
+	matrix := &VersionMatrix{Environments: environments, AppServers: []VersionMatrixRow{}}
+	byAppServer := map[string][]*DeploymentResponse{}
+	for i := range deployments {
+		for j := range deployments[i] {
+			name := deployments[i][j].AppServerName
+			if _, found := byAppServer[name]; !found {
+				byAppServer[name] = make([]*DeploymentResponse, len(environments))
+			}
+			byAppServer[name][i] = &deployments[i][j]
+		}
+	}
+	names := []string{}
+	for name := range byAppServer {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		row := VersionMatrixRow{AppServerName: name, Cells: []VersionMatrixCell{}}
+		for i, deployment := range byAppServer[name] {
+			cell := VersionMatrixCell{Environment: environments[i], Versions: map[string]string{}}
+			if deployment != nil {
+				cell.Deployed = true
+				cell.Release = deployment.ReleaseName
+				cell.Versions = deployment.versions()
+			}
+			if i > 0 {
+				previous := row.Cells[i-1]
+				cell.Changed = previous.Deployed != cell.Deployed || len(compareVersions(previous.Versions, cell.Versions)) > 0
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		matrix.AppServers = append(matrix.AppServers, row)
+	}
+	return matrix
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestNewVersionMatrixMarksChangedCells(t *testing.T) {
+
+	// given
+	environments := []string{"B", "Y", "P"}
+	batch := newTestDeployment(4, 0, DeploymentStateSuccess, "batch", "3.0")
+	batch.AppServerName = "Batch"
+	deployments := []Deployments{
+		{newTestDeployment(1, 0, DeploymentStateSuccess, "testapp", "1.1", "other", "2.0"), batch},
+		{newTestDeployment(2, 0, DeploymentStateSuccess, "other", "2.0", "testapp", "1.1")},
+		{newTestDeployment(3, 0, DeploymentStateSuccess, "testapp", "1.0", "other", "2.0")},
+	}
+
+	// when
+	matrix := newVersionMatrix(environments, deployments)
+
+	// then
+	if len(matrix.AppServers) != 2 {
+		t.Fatalf("Expecting 2 app servers, got %v", matrix.AppServers)
+	}
+	assertString(t, "Batch", matrix.AppServers[0].AppServerName, "app server")
+	assertString(t, "Test", matrix.AppServers[1].AppServerName, "app server")
+
+	cells := matrix.AppServers[0].Cells
+	assertString(t, "batch 3.0", cells[0].VersionsText(", "), "versions on B")
+	assertString(t, "-", cells[1].VersionsText(", "), "versions on Y")
+	if cells[0].Changed || !cells[1].Changed || cells[2].Changed {
+		t.Errorf("Expecting only Batch on Y changed, got %v", cells)
+	}
+
+	cells = matrix.AppServers[1].Cells
+	assertString(t, "other 2.0, testapp 1.1", cells[1].VersionsText(", "), "versions on Y")
+	if cells[0].Changed || cells[1].Changed || !cells[2].Changed {
+		t.Errorf("Expecting only Test on P changed, got %v", cells)
+	}
+}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+//NewAppCmd is a command to look up applications
+func NewAppCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "app COMMAND",
+		Short: "Look up applications",
+	}
+
+	cmd.AddCommand(newWhereCommand(cli))
+
+	return cmd
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	whereLong = `	Show where an application is deployed and in which version.
+	The latest deployment of the application to each application server on each environment is printed
+	as matrix of the environments (rows) and application servers (columns) with the version and the state of the deployment.`
+
+	//Example command description
+	whereExample = `	# Show the versions of the application testapp
+	liimactl app where testapp
+	# Print JSON
+	liimactl app where testapp --output=json`
+
+	//Flags of the command
+	whereOutput string
+)
+
+//newWhereCommand is a command to show the versions of an application on all environments
+func newWhereCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "where [flags] appName",
+		Short:   "Show where and in which version an application is deployed",
+		Long:    whereLong,
+		Example: whereExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runWhere(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&whereOutput, "output", "o", "text", "Output format: text or json")
+
+	return cmd
+}
+
+//Get the latest deployments of the application and print them on the console
+func runWhere(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	locations, err := client.WhereApplication(cli, &client.CommandOptionsWhereApplication{AppName: args[0]})
+	if err != nil {
+		log.Fatalf("Couldn't get deployments of application: %v", err)
+	}
+
+	switch whereOutput {
+	case "json":
+		data, err := json.MarshalIndent(locations, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		//JSON to stdout, used in pipes
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "text":
+		printLocations(cmd, locations)
+	default:
+		log.Fatalf("Unknown output format: %s", whereOutput)
+	}
+}
+
+//printLocations prints the matrix of the environments and app servers
+func printLocations(cmd *cobra.Command, locations *client.ApplicationLocations) {
+	if len(locations.Locations) == 0 {
+		cmd.Printf("Application %s is not deployed\n", locations.ApplicationName)
+		return
+	}
+
+	table := report.TextTable{Header: append([]string{"Environment"}, locations.AppServers...)}
+	for _, environment := range locations.Environments {
+		row := []string{environment}
+		for _, appServer := range locations.AppServers {
+			cell := "-"
+			if location, found := locations.Location(environment, appServer); found {
+				cell = fmt.Sprintf("%s (%s)", location.Version, location.State)
+			}
+			row = append(row, cell)
+		}
+		table.AddRow(row...)
+	}
+	if err := table.Write(cmd.OutOrStdout()); err != nil {
+		log.Fatal(err)
+	}
+}
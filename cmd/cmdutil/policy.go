@@ -0,0 +1,28 @@
+/*
+Package cmdutil contains the flags and helpers shared by the commands.
+*/
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//AddPolicyFlags adds the flags of the policy check (see PolicyFile in the config)
+func AddPolicyFlags(flags *pflag.FlagSet, options *client.PolicyOptions) {
+	flags.StringVar(&options.Reason, "reason", "", "Reason of the deployment, required by the policy of some environments")
+	flags.BoolVar(&options.OverridePolicy, "override-policy", false, "Deploy despite policy violations, the override is recorded in the audit log with the reason")
+	flags.BoolVar(&options.ShiftToWindow, "shift-to-window", false, "Shift a deployment date outside the policy windows or in a freeze to the next allowed time")
+}
+
+//WithPolicyHint adds the flags resolving a policy violation to the error, other errors are returned unchanged
+func WithPolicyHint(err error) error {
+	var violation *client.PolicyViolationError
+	if errors.As(err, &violation) {
+		return fmt.Errorf("%w (use --shift-to-window or --override-policy with --reason)", err)
+	}
+	return err
+}
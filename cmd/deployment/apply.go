@@ -0,0 +1,95 @@
+package deployment
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	deploymentApplyLong = `	Deploy a snapshot created with "liimactl snapshot" on an environment.
+	Each application server of the snapshot is deployed with its release, application versions and deployment parameters.`
+
+	//Example command description
+	deploymentApplyExample = `	# Deploy a snapshot on the environment of the snapshot (disaster recovery)
+	liimactl deployment apply -f p-2026-10.yaml --wait
+	# Deploy a snapshot on another environment
+	liimactl deployment apply -f p-2026-10.yaml --environment=Y --date="tomorrow 06:00"
+	# Deploy only some application servers of the snapshot
+	liimactl deployment apply -f p-2026-10.yaml --appServer=aps_bau,vvn`
+
+	//Flags of the command
+	commandOptionsApply client.CommandOptionsApplySnapshot
+	applyFile           string
+	applySilent         bool
+)
+
+//newApplyCommand is a command to deploy a snapshot
+func newApplyCommand(cli *client.Cli) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "apply [flags] ",
+		Short:   "Deploy a snapshot",
+		Long:    deploymentApplyLong,
+		Example: deploymentApplyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runApply(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&applyFile, "file", "f", "", "Snapshot file")
+	cmd.Flags().StringVarP(&commandOptionsApply.Environment, "environment", "e", "", "Environment, default is the environment of the snapshot")
+	cmd.Flags().StringSliceVarP(&commandOptionsApply.AppServer, "appServer", "a", []string{}, "Only the given application servers of the snapshot, unknown names are an error")
+	cmd.Flags().StringVarP(&commandOptionsApply.DeploymentDate, "date", "d", "", "Deployment Date 'YYYY-MM-DD hh:mm', 'DD.MM.YYYY hh:mm', ISO-8601, '+2h' or 'tomorrow 06:00'")
+	cmd.Flags().StringVar(&commandOptionsApply.TimeZone, "timezone", "", "Timezone of the deployment date, example: 'Europe/Zurich' (default local timezone)")
+	cmd.Flags().BoolVarP(&commandOptionsApply.ExecuteShakedownTest, "executeShakeDownTest", "s", false, "Run Shakedowntest after the deployment")
+	cmd.Flags().BoolVarP(&commandOptionsApply.Wait, "wait", "w", false, "Wait maxWaitTime until the deployment success or failed")
+	cmd.Flags().IntVarP(&commandOptionsApply.MaxWaitTime, "maxWaitTime", "t", 600, "Max Wait time [seconds] until the deployment success or failed")
+	cmd.Flags().BoolVarP(&applySilent, "silent", "c", false, "Silent mode, no confirmation of the deployments")
+	cmdutil.AddPolicyFlags(cmd.Flags(), &commandOptionsApply.PolicyOptions)
+
+	return cmd
+}
+
+//Deploy a snapshot and print the state of each deployment on the console
+func runApply(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	file, err := os.Open(applyFile)
+	if err != nil {
+		log.Fatal("Couldn't open snapshot: ", err)
+	}
+	defer file.Close()
+	snapshot, err := client.ReadSnapshot(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	//Ask user for confirmation
+	environment := commandOptionsApply.Environment
+	if environment == "" {
+		environment = snapshot.Environment
+	}
+	msg := fmt.Sprintf("Do you really want to deploy the snapshot of environment %s on environment: %s", snapshot.Environment, environment)
+	if !applySilent && !cmdutil.AskYesNo(msg) {
+		return
+	}
+
+	deployments, err := client.ApplySnapshot(cli, &commandOptionsApply, snapshot)
+	if err != nil {
+		log.Fatal("Error Apply Snapshot: ", cmdutil.WithPolicyHint(err))
+	}
+	success := true
+	for _, deployment := range deployments {
+		PrintDeployment(cmd, &deployment)
+		success = success && deployment.State != client.DeploymentStateFailed
+	}
+
+	//Write failed -> return code = 1 with log.Fatal
+	if !success {
+		log.Fatal("Apply failed, not all deployments are successfully")
+	}
+}
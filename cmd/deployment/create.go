@@ -2,8 +2,10 @@ package deployment
 
 import (
 	"log"
+	"time"
 
 	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
@@ -15,10 +17,14 @@ var (
 	deploymentCreateExample = `	# Create a deployment with specific properties. 
 	liimactl deployment create --appServer=test_application --appName=ch_mobi_app1 --version="1.0.0" --appName=ch_mobi_app2 --version="1.0.1" --environment=I
 	liimactl deployment create --appServer=aps_bau --appName=ch_mobi_aps_bau --version="1.0.32" --environment=W --date="2018-02-01 16:00"
-	liimactl deployment create --appServer=generic_test --appName=ch_mobi_generic_test --version="1.0.1" --environment=U --wait`
+	liimactl deployment create --appServer=aps_bau --appName=ch_mobi_aps_bau --version="1.0.32" --environment=W --date="tomorrow 06:00" --timezone=Europe/Zurich
+	liimactl deployment create --appServer=generic_test --appName=ch_mobi_generic_test --version="1.0.1" --environment=U --wait
+	liimactl deployment create --appServer=generic_test --appName=ch_mobi_generic_test --version="1.0.1" --environment=U --wait --report=junit=deployment.xml --report=markdown=deployment.md
+	liimactl deployment create --appServer=generic_test --appName=ch_mobi_generic_test --version="1.0.1" --environment=P --date="tomorrow 06:00" --shift-to-window --reason="Hotfix INC-42"`
 
 	//Flags of the command
 	commandOptionsCreate client.CommandOptionsCreateDeployment
+	createReports        []string
 )
 
 //newCreateCommand is a command to create a deployment
@@ -38,13 +44,16 @@ func newCreateCommand(cli *client.Cli) *cobra.Command {
 	cmd.Flags().StringSliceVarP(&commandOptionsCreate.AppVersion, "version", "v", []string{}, "Application Version")
 	cmd.Flags().StringVarP(&commandOptionsCreate.Environment, "environment", "e", "", "Environment")
 	cmd.Flags().StringVarP(&commandOptionsCreate.Release, "release", "r", "", "Release")
-	cmd.Flags().StringVarP(&commandOptionsCreate.DeploymentDate, "date", "d", "", "Deployment Date 'YYYY.MM.DD hh:mm' ")
+	cmd.Flags().StringVarP(&commandOptionsCreate.DeploymentDate, "date", "d", "", "Deployment Date 'YYYY-MM-DD hh:mm', 'DD.MM.YYYY hh:mm', ISO-8601, '+2h' or 'tomorrow 06:00'")
+	cmd.Flags().StringVar(&commandOptionsCreate.TimeZone, "timezone", "", "Timezone of the deployment date, example: 'Europe/Zurich' (default local timezone)")
 	cmd.Flags().BoolVarP(&commandOptionsCreate.ExecuteShakedownTest, "executeShakeDownTest", "s", false, "Run Shakedowntest after the deployment")
 	cmd.Flags().StringSliceVarP(&commandOptionsCreate.Key, "key", "k", []string{}, "Deploymentparameter Key")
 	cmd.Flags().StringSliceVarP(&commandOptionsCreate.Value, "value", "x", []string{}, "Deploymentparameter Value")
 	cmd.Flags().BoolVarP(&commandOptionsCreate.Wait, "wait", "w", false, "Wait maxWaitTime until the deployment success or failed")
 	cmd.Flags().IntVarP(&commandOptionsCreate.MaxWaitTime, "maxWaitTime", "t", 600, "Max Wait time [seconds] until the deployment success or failed")
 	cmd.Flags().StringVarP(&commandOptionsCreate.FromEnvironment, "fromEnvironment", "f", "", "Deploy last deployment from given environment")
+	cmd.Flags().StringArrayVar(&createReports, "report", []string{}, reportFlagUsage)
+	cmdutil.AddPolicyFlags(cmd.Flags(), &commandOptionsCreate.PolicyOptions)
 
 	return cmd
 }
@@ -52,10 +61,27 @@ func newCreateCommand(cli *client.Cli) *cobra.Command {
 //Get the deployments properties given by the arguments (see type deployments) and print it on the console
 func runCreate(cmd *cobra.Command, cli *client.Cli, args []string) {
 
+	if err := validateReportFlags(createReports); err != nil {
+		log.Fatal(err)
+	}
+
 	//Create deployment
+	start := time.Now()
 	deployment, err := client.CreateDeployment(cli, &commandOptionsCreate)
+
+	//Write reports, also on errors
+	run := deploymentRun{Name: "create " + commandOptionsCreate.AppServer, Environment: commandOptionsCreate.Environment, Duration: time.Since(start)}
+	if err != nil {
+		run.Err = &client.CreateDeploymentError{AppServerName: commandOptionsCreate.AppServer, Err: err}
+	} else {
+		run.Deployments = client.Deployments{*deployment}
+	}
+	if reportErr := writeReports(createReports, &run); reportErr != nil {
+		log.Print(reportErr)
+	}
+
 	if err != nil {
-		log.Fatal("Error Create Deployment: ", err)
+		log.Fatal("Error Create Deployment: ", cmdutil.WithPolicyHint(err))
 	}
 
 	//Print result
@@ -1,10 +1,6 @@
 package deployment
 
 import (
-	"fmt"
-	"strings"
-	"time"
-
 	"github.com/liimaorg/liimactl/client"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +16,9 @@ func NewDeploymentCmd(cli *client.Cli) *cobra.Command {
 	DeploymentCmd.AddCommand(newGetCommand(cli))
 	DeploymentCmd.AddCommand(newCreateCommand(cli))
 	DeploymentCmd.AddCommand(newPromoteCommand(cli))
+	DeploymentCmd.AddCommand(newHistoryCommand(cli))
+	DeploymentCmd.AddCommand(newRollbackCommand(cli))
+	DeploymentCmd.AddCommand(newApplyCommand(cli))
 
 	return DeploymentCmd
 }
@@ -40,33 +39,17 @@ func PrintDeployment(cmd *cobra.Command, deployment *client.DeploymentResponse)
 		cmd.Printf("%s ", deployment.ReleaseName)
 	}
 	if deployment.DeploymentDate != 0 {
-		cmd.Printf("%s ", time.Unix(0, deployment.DeploymentDate*int64(time.Millisecond)).Format("2006-01-02T15:04"))
+		cmd.Printf("%s ", deployment.DeploymentTime().Format(client.DisplayDateTimeFormat))
 	}
 	if deployment.State != "" {
 		cmd.Println(deployment.State)
 	}
+	if deployment.StatusMessage != "" {
+		cmd.Println(deployment.StatusMessage)
+	}
 	for _, appsWithVersion := range deployment.AppsWithVersion {
 		cmd.Printf("%s ", appsWithVersion.ApplicationName)
 		cmd.Println(appsWithVersion.Version)
 	}
 
 }
-
-//AskYesNo want's a user confirmation yes from the console
-func AskYesNo(message string) bool {
-	var s string
-
-	fmt.Printf("%s [y/n]: ", message)
-	_, err := fmt.Scan(&s)
-	if err != nil {
-		panic(err)
-	}
-
-	s = strings.TrimSpace(s)
-	s = strings.ToLower(s)
-
-	if s == "y" || s == "yes" {
-		return true
-	}
-	return false
-}
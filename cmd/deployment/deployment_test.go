@@ -3,8 +3,11 @@ package deployment
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/liimaorg/liimactl/client"
 	"github.com/spf13/pflag"
@@ -22,6 +25,10 @@ func TestNewDeploymentGetCmd(t *testing.T) {
 		{"Test1", []string{"get", "--appServer=testApp"}, "------\nTest"},
 		{"Test2", []string{"get", "--appServer=testApp2", "--environment=T"}, "------\nTest"},
 		{"Test3", []string{"get", "--filter=[{\"name\":\"Environment\",\"comp\":\"eq\",\"val\":\"Y\"},{\"name\":\"Application server\",\"comp\":\"eq\",\"val\":\"testApp3\"}]"}, "------\nTest"},
+		{"Test4", []string{"get", "--environment=P", "--since=24h"}, "------\nTest"},
+		{"Test5", []string{"get", "--environment=P", "--on=2026-10-17"}, "------\nTest"},
+		{"Test6", []string{"history", "--appServer=Test", "--environment=P"}, "------\n"},
+		{"Test7", []string{"history", "--appServer=Test", "--environment=P", "--onlyVersionChanges"}, "------\n"},
 	}
 
 	//Init config
@@ -77,6 +84,11 @@ func TestNewDeploymentCreateCmd(t *testing.T) {
 		{"Test3", []string{"promote", "--environment=Y", "--fromEnvironment=B", "--date=2018-02-01 17:00", "-c", "--blacklistAppServer=Test"}, ""},
 		{"Test4", []string{"promote", "--environment=Y", "--fromEnvironment=B", "--date=2018-02-01 17:00", "-c", "--blacklistAppServer=Test2", "--whitelistAppServer=Test"}, "------\nsuccess\n"},
 		{"Test5", []string{"promote", "--environment=Y", "--fromEnvironment=B", "--date=2018-02-01 17:00", "-c", "--wait"}, "------\nTest success\ntestapp 1.0\n"},
+		{"Test6", []string{"rollback", "--appServer=Test", "--environment=Y", "-c"}, "Nothing to roll back\n"},
+		{"Test7", []string{"rollback", "--all", "--environment=Y", "--dryRun"}, "Nothing to roll back\n"},
+		{"Test8", []string{"promote", "--environment=Y", "--fromEnvironment=B", "-c", "--blacklistAppServer=re:^Te"}, ""},
+		{"Test9", []string{"promote", "--environment=Y", "--fromEnvironment=B", "--whitelistAppServer=T*", "--explain-selection"}, fmt.Sprintf("%-30s %-20s %-9s %s\n", "Test", "", "included", `included, whitelistAppServer "T*"`)},
+		{"Test10", []string{"promote", "--environment=Y", "--fromEnvironment=B", "--blacklistApplication=test*", "--explain-selection"}, fmt.Sprintf("%-30s %-20s %-9s %s\n", "Test", "", "excluded", `application testapp in blacklistApplication "test*"`)},
 	}
 
 	//Init config
@@ -119,6 +131,129 @@ func TestNewDeploymentCreateCmd(t *testing.T) {
 
 }
 
+//Tests the command "deployment apply"
+func TestNewDeploymentApplyCmd(t *testing.T) {
+
+	//Write snapshot file
+	file, err := os.CreateTemp(t.TempDir(), "snapshot*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("environment: P\nappServers:\n  - name: Test\n    release: RL-19.04\n    applications:\n      - name: testapp\n        version: \"1.0\"\n")
+	file.Close()
+
+	//Create mock client
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, _ := initConfig(flags)
+	liimacli.Client, _ = client.NewMockClient(config)
+
+	//Create command
+	cmd := NewDeploymentCmd(liimacli)
+	buf := new(bytes.Buffer)
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"apply", "-f", file.Name(), "--environment=Y", "-c"})
+
+	//Execute command
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("Execute() failed with %v", err)
+	}
+	//Check result
+	if got, want := buf.String(), "------\nsuccess\n"; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+//Tests the flag --report of "deployment create" and "deployment promote"
+func TestDeploymentReports(t *testing.T) {
+
+	dir := t.TempDir()
+	junit := filepath.Join(dir, "promote.xml")
+	markdown := filepath.Join(dir, "create.md")
+
+	//Create mock client
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, _ := initConfig(flags)
+	liimacli.Client, _ = client.NewMockClient(config)
+
+	//Execute commands
+	for _, args := range [][]string{
+		{"promote", "--environment=Y", "--fromEnvironment=B", "-c", "--wait", "--report=junit=" + junit},
+		{"create", "--appServer=testApp", "--environment=T", "--appName=test1", "--version=1.1.1", "--report=markdown=" + markdown},
+	} {
+		cmd := NewDeploymentCmd(liimacli)
+		cmd.SetOutput(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("Execute() failed with %v", err)
+		}
+	}
+
+	//Check reports
+	if got, _ := os.ReadFile(junit); !strings.Contains(string(got), `<testsuite name="promote Y" tests="1" failures="0" errors="0"`) || !strings.Contains(string(got), `<testcase name="Test" classname="deployment.Y"`) {
+		t.Errorf("JUnit report = %s", got)
+	}
+	if got, _ := os.ReadFile(markdown); !strings.HasPrefix(string(got), "## create testApp\n\n| App server | Environment | State |") {
+		t.Errorf("Markdown report = %s", got)
+	}
+
+	//Rejected deployments are skipped, creation errors are errors
+	run := deploymentRun{Name: "promote Y", Environment: "Y", Deployments: client.Deployments{{AppServerName: "kube", State: client.DeploymentStateRejected}, {AppServerName: "aps_bau", State: client.DeploymentStateSuccess, Duration: 75 * time.Second}}, Duration: 90 * time.Second}
+	run.Err = &client.CreateDeploymentError{AppServerName: "vvn", Err: fmt.Errorf("500 Internal Server Error")}
+	suite := run.testSuite()
+	if suite.Tests != 3 || suite.Errors != 1 || suite.TestCases[0].Skipped == nil || suite.TestCases[2].Name != "vvn" {
+		t.Errorf("Test suite = %v", suite)
+	}
+	//The test cases have the duration of their deployment, the suite the duration of the run
+	if suite.Time != 90 || suite.TestCases[0].Time != 0 || suite.TestCases[1].Time != 75 {
+		t.Errorf("Test suite time = %v, test case times = %v and %v, want 90, 0 and 75", suite.Time, suite.TestCases[0].Time, suite.TestCases[1].Time)
+	}
+	markdownReport := new(bytes.Buffer)
+	if err := run.writeMarkdown(markdownReport); err != nil || !strings.Contains(markdownReport.String(), "| aps_bau |  | success | 1m15s |") {
+		t.Errorf("Markdown report = %s, error %v", markdownReport, err)
+	}
+
+	if err := validateReportFlags([]string{"html=report.html"}); err == nil {
+		t.Errorf("Expecting an error on an unknown report format")
+	}
+}
+
+//Tests the conversion of --since, --until and --on to the deployment date range
+func TestSetDeploymentDateRange(t *testing.T) {
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	defer func() { deploymentSince, deploymentUntil, deploymentOn = "", "", "" }()
+
+	//A plain date of --until includes the whole day
+	deploymentSince, deploymentUntil, deploymentOn = "2026-10-01", "2026-10-10", ""
+	commandOptions := client.CommandOptionsGetDeployment{}
+	if err := setDeploymentDateRange(&commandOptions, now); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if want := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC); !commandOptions.DeploymentTo.Equal(want) {
+		t.Errorf("DeploymentTo = %v, want %v", commandOptions.DeploymentTo, want)
+	}
+	if want := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC); !commandOptions.DeploymentFrom.Equal(want) {
+		t.Errorf("DeploymentFrom = %v, want %v", commandOptions.DeploymentFrom, want)
+	}
+
+	//A date with time is used as given
+	deploymentUntil = "2026-10-10 08:00"
+	if err := setDeploymentDateRange(&commandOptions, now); err != nil {
+		t.Fatalf("Excepting no error: %s", err)
+	}
+	if want := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC); !commandOptions.DeploymentTo.Equal(want) {
+		t.Errorf("DeploymentTo = %v, want %v", commandOptions.DeploymentTo, want)
+	}
+
+	//The date flags are ignored by the JSON filter
+	commandOptions.Filter = []client.DeploymentFilter{{Name: "Environment", Comp: client.Eq, Val: "Y"}}
+	if err := setDeploymentDateRange(&commandOptions, now); err == nil {
+		t.Errorf("Expecting an error for --until with --filter")
+	}
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
 
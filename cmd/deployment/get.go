@@ -2,10 +2,13 @@ package deployment
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"sort"
+	"time"
 
 	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/util"
 	"github.com/spf13/cobra"
 )
 
@@ -19,11 +22,19 @@ var (
 	# Filters can also be passed as JSON
 	liimactl deployment get --filter='[{"name":"Environment","comp":"eq","val":"Y"},{"name":"Application server","comp":"eq","val":"liima"}]'
 	liimactl deployment get --filter='[{"name":"Environment","comp":"eq","val":"Y"},{"name":"Latest deployment job for App Server and Env","comp":"eq","val":"true"}]'
+	# Get the deployments of the last 24 hours or of a given day (local timezone)
+	liimactl deployment get --environment=P --since=24h
+	liimactl deployment get --environment=P --since=7d --until=2d
+	liimactl deployment get --environment=P --since="2026-10-01 08:00" --until=2026-10-10
+	liimactl deployment get --environment=P --on=2026-10-17
 	`
 	//Flags of the command
 	commandOptionsGet client.CommandOptionsGetDeployment
 	deploymentFilter  string
 	deploymentState   *[]string
+	deploymentSince   string
+	deploymentUntil   string
+	deploymentOn      string
 )
 
 //newGetCommand is a command to get deployments
@@ -47,6 +58,9 @@ func newGetCommand(cli *client.Cli) *cobra.Command {
 	cmd.Flags().IntVarP(&commandOptionsGet.TrackingID, "trackingId", "t", -1, "Tracking ID")
 	cmd.Flags().IntSliceVarP(&commandOptionsGet.ID, "id", "i", []int{}, "Deployment ID")
 	cmd.Flags().StringVarP(&deploymentFilter, "filter", "f", "", "Deployment filter in JSON")
+	cmd.Flags().StringVar(&deploymentSince, "since", "", "Only deployments since a duration ago (24h, 7d) or a date 'YYYY-MM-DD [hh:mm]'")
+	cmd.Flags().StringVar(&deploymentUntil, "until", "", "Only deployments before a duration ago (24h, 7d) or a date 'YYYY-MM-DD [hh:mm]', a date without time includes the whole day")
+	cmd.Flags().StringVar(&deploymentOn, "on", "", "Only deployments on the given day 'YYYY-MM-DD'")
 
 	return cmd
 }
@@ -57,12 +71,16 @@ func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
 	for _, state := range *deploymentState {
 		commandOptionsGet.DeploymentState = append(commandOptionsGet.DeploymentState, client.DeploymentState(state))
 	}
+	commandOptionsGet.Filter = nil
 	if deploymentFilter != "" {
 		err := json.Unmarshal([]byte(deploymentFilter), &commandOptionsGet.Filter)
 		if err != nil {
 			log.Fatalf("Filter is not valid: %v", err)
 		}
 	}
+	if err := setDeploymentDateRange(&commandOptionsGet, time.Now()); err != nil {
+		log.Fatal(err)
+	}
 
 	//Get deployments
 	deployments, err := client.GetDeployment(cli, &commandOptionsGet)
@@ -77,3 +95,37 @@ func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
 	}
 
 }
+
+//setDeploymentDateRange converts the flags since, until and on to the deployment date range of the command options
+//A plain date given to until includes the whole day
+func setDeploymentDateRange(commandOptions *client.CommandOptionsGetDeployment, now time.Time) error {
+	var err error
+	commandOptions.DeploymentFrom = time.Time{}
+	commandOptions.DeploymentTo = time.Time{}
+
+	//The JSON filter replaces all other filters
+	if len(commandOptions.Filter) != 0 && (deploymentOn != "" || deploymentSince != "" || deploymentUntil != "") {
+		return fmt.Errorf("--since, --until and --on can't be combined with --filter")
+	}
+
+	if deploymentOn != "" {
+		if deploymentSince != "" || deploymentUntil != "" {
+			return fmt.Errorf("--on can't be combined with --since or --until")
+		}
+		commandOptions.DeploymentFrom, commandOptions.DeploymentTo, err = util.ParseDay(deploymentOn, now.Location())
+		return err
+	}
+	if deploymentSince != "" {
+		if commandOptions.DeploymentFrom, err = util.ParsePointInTime(deploymentSince, now); err != nil {
+			return err
+		}
+	}
+	if deploymentUntil != "" {
+		if _, endOfDay, err := util.ParseDay(deploymentUntil, now.Location()); err == nil {
+			commandOptions.DeploymentTo = endOfDay
+		} else if commandOptions.DeploymentTo, err = util.ParsePointInTime(deploymentUntil, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package deployment
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	deploymentHistoryLong = `	Show the deployment history of an application server on an environment.
+	Each deployment shows the application versions compared to the last successful deployment before.`
+
+	//Example command description
+	deploymentHistoryExample = `	# Show all deployments of an application server on an environment
+	liimactl deployment history --appServer=test_application --environment=P
+	# Show only the deployments which changed the versions (without redeploys of the same versions)
+	liimactl deployment history --appServer=test_application --environment=P --onlyVersionChanges`
+
+	//Flags of the command
+	commandOptionsHistory client.CommandOptionsDeploymentHistory
+)
+
+//newHistoryCommand is a command to show the deployment history of an application server
+func newHistoryCommand(cli *client.Cli) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "history [flags] ",
+		Short:   "Show the deployment history of an application server",
+		Long:    deploymentHistoryLong,
+		Example: deploymentHistoryExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runHistory(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptionsHistory.AppServer, "appServer", "a", "", "Application Server Name")
+	cmd.Flags().StringVarP(&commandOptionsHistory.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().BoolVarP(&commandOptionsHistory.OnlyVersionChanges, "onlyVersionChanges", "c", false, "Only deployments which changed the versions")
+
+	return cmd
+}
+
+//Get the deployment history and print it on the console
+func runHistory(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	history, err := client.GetDeploymentHistory(cli, &commandOptionsHistory)
+	if err != nil {
+		log.Fatal("Error Deployment History: ", err)
+	}
+
+	for _, entry := range history {
+		PrintHistoryEntry(cmd, &entry)
+	}
+}
+
+//PrintHistoryEntry prints out a deployment of the history with its version changes
+func PrintHistoryEntry(cmd *cobra.Command, entry *client.DeploymentHistoryEntry) {
+	deployment := entry.Deployment
+
+	cmd.Println("------")
+	cmd.Printf("%s %-16s %-10s #%d", deployment.DeploymentTime().Format(client.DisplayDateTimeFormat), deployment.State, deployment.ReleaseName, deployment.ID)
+	if deployment.RequestUser != "" {
+		cmd.Printf(" requested: %s", deployment.RequestUser)
+	}
+	if deployment.ConfirmUser != "" {
+		cmd.Printf(" confirmed: %s", deployment.ConfirmUser)
+	}
+	cmd.Println()
+
+	changed := map[string]client.VersionChange{}
+	for _, change := range entry.Changes {
+		changed[change.ApplicationName] = change
+	}
+	for _, appsWithVersion := range deployment.AppsWithVersion {
+		if change, ok := changed[appsWithVersion.ApplicationName]; ok && change.OldVersion != "" {
+			cmd.Printf("%s %s -> %s\n", appsWithVersion.ApplicationName, change.OldVersion, change.NewVersion)
+		} else if ok {
+			cmd.Printf("%s %s (new)\n", appsWithVersion.ApplicationName, appsWithVersion.Version)
+		} else {
+			cmd.Printf("%s %s\n", appsWithVersion.ApplicationName, appsWithVersion.Version)
+		}
+	}
+	for _, change := range entry.Changes {
+		if change.NewVersion == "" {
+			cmd.Printf("%s %s (removed)\n", change.ApplicationName, change.OldVersion)
+		}
+	}
+}
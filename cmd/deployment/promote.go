@@ -5,8 +5,10 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
@@ -19,12 +21,22 @@ var (
 	liimactl deployment promote --environment=Y  --fromEnvironment=B
 	liimactl deployment promote --environment=Y  --fromEnvironment=B --date="2018-02-01 17:00" --blacklistRuntime="Kubernetes,Kube_helm"
 	liimactl deployment promote --environment=Y  --fromEnvironment=B --date="2018-02-01 17:00" --blacklistAppServer="aps_bau_kube,vvn"
-	liimactl deployment promote --environment=Z  --fromEnvironment=I --whitelistAppServer="appServer1,appServer2" --wait --maxWaitTime=3600`
+	liimactl deployment promote --environment=Y  --fromEnvironment=B --date="+2h"
+	liimactl deployment promote --environment=Z  --fromEnvironment=I --whitelistAppServer="appServer1,appServer2" --wait --maxWaitTime=3600
+	liimactl deployment promote --environment=Y  --fromEnvironment=B --wait --report=junit=promote.xml --report=markdown=promote.md
+	liimactl deployment promote --environment=P  --fromEnvironment=B --reason="Security fix" --override-policy
+	liimactl deployment promote --environment=P  --fromEnvironment=B --minDeploymentAge=2d --requireShakedownTestRun
+	liimactl deployment promote --environment=Y  --fromEnvironment=B --whitelistAppServer="aps_*" --blacklistRuntime="re:^kube_.*" --blacklistApplication=@excluded-apps.txt --explain-selection`
 
 	//Flags of the command
 	commandOptionsPromote client.CommandOptionsPromoteDeployments
+	promoteReports        []string
+	explainSelection      bool
 )
 
+//patternUsage is appended to the usage of the white- and blacklist flags
+const patternUsage = ", patterns: 'aps_*', 're:^kube_.*' or '@file' with one pattern per line"
+
 //newPromoteCommand is a command to promote multiple deployments on an environment
 func newPromoteCommand(cli *client.Cli) *cobra.Command {
 	var cmd = &cobra.Command{
@@ -39,14 +51,23 @@ func newPromoteCommand(cli *client.Cli) *cobra.Command {
 
 	cmd.Flags().StringVarP(&commandOptionsPromote.Environment, "environment", "e", "", "Environment")
 	cmd.Flags().StringVarP(&commandOptionsPromote.FromEnvironment, "fromEnvironment", "f", "", "Deploy last deployment from given environment")
-	cmd.Flags().StringVarP(&commandOptionsPromote.DeploymentDate, "date", "d", "", "Deployment Date 'DD.MM.YYYY hh:mm' ")
+	cmd.Flags().StringVarP(&commandOptionsPromote.DeploymentDate, "date", "d", "", "Deployment Date 'YYYY-MM-DD hh:mm', 'DD.MM.YYYY hh:mm', ISO-8601, '+2h' or 'tomorrow 06:00'")
+	cmd.Flags().StringVar(&commandOptionsPromote.TimeZone, "timezone", "", "Timezone of the deployment date, example: 'Europe/Zurich' (default local timezone)")
 	cmd.Flags().BoolVarP(&commandOptionsPromote.ExecuteShakedownTest, "executeShakeDownTest", "s", false, "Run Shakedowntest after the deployment")
 	cmd.Flags().BoolVarP(&commandOptionsPromote.Wait, "wait", "w", false, "Wait maxWaitTime until the deployment success or failed")
 	cmd.Flags().IntVarP(&commandOptionsPromote.MaxWaitTime, "maxWaitTime", "t", 600, "Max Wait time [seconds] until the deployment success or failed")
-	cmd.Flags().StringSliceVarP(&commandOptionsPromote.WhitelistAppServer, "whitelistAppServer", "a", []string{}, "Whitelist with all appServer, which should be deployed, if no WhitelistAppServer is defined, the whole environment will deployed (exclusive blacklist)")
-	cmd.Flags().StringSliceVarP(&commandOptionsPromote.BlacklistAppServer, "blacklistAppServer", "b", []string{}, "Blacklist with all appServer, which should not be deployed")
-	cmd.Flags().StringSliceVarP(&commandOptionsPromote.BlacklistRuntime, "blacklistRuntime", "r", []string{}, "Blacklist with all runtimes, which should not be deployed")
+	cmd.Flags().StringSliceVarP(&commandOptionsPromote.WhitelistAppServer, "whitelistAppServer", "a", []string{}, "Whitelist with all appServer, which should be deployed, if no WhitelistAppServer is defined, the whole environment will deployed (exclusive blacklist)"+patternUsage)
+	cmd.Flags().StringSliceVarP(&commandOptionsPromote.BlacklistAppServer, "blacklistAppServer", "b", []string{}, "Blacklist with all appServer, which should not be deployed"+patternUsage)
+	cmd.Flags().StringSliceVar(&commandOptionsPromote.WhitelistRuntime, "whitelistRuntime", []string{}, "Whitelist with all runtimes, which should be deployed"+patternUsage)
+	cmd.Flags().StringSliceVarP(&commandOptionsPromote.BlacklistRuntime, "blacklistRuntime", "r", []string{}, "Blacklist with all runtimes, which should not be deployed"+patternUsage)
+	cmd.Flags().StringSliceVar(&commandOptionsPromote.WhitelistApplication, "whitelistApplication", []string{}, "Only deploy appServer with at least one of these applications"+patternUsage)
+	cmd.Flags().StringSliceVar(&commandOptionsPromote.BlacklistApplication, "blacklistApplication", []string{}, "Don't deploy appServer with one of these applications"+patternUsage)
+	cmd.Flags().BoolVar(&explainSelection, "explain-selection", false, "Show why each appServer is promoted or not, without deploying")
 	cmd.Flags().BoolVarP(&commandOptionsPromote.Silent, "silent", "c", false, "Silent mode, no confirmation of promote the whole environment")
+	cmd.Flags().StringVar(&commandOptionsPromote.MinDeploymentAge, "minDeploymentAge", "", "Only promote deployments older than the minimum age on the source environment, example '12h' or '2d'")
+	cmd.Flags().BoolVar(&commandOptionsPromote.RequireShakedownTestRun, "requireShakedownTestRun", false, "Only promote deployments run with a shakedown test on the source environment, the result of the test isn't checked")
+	cmd.Flags().StringArrayVar(&promoteReports, "report", []string{}, reportFlagUsage)
+	cmdutil.AddPolicyFlags(cmd.Flags(), &commandOptionsPromote.PolicyOptions)
 
 	return cmd
 }
@@ -54,14 +75,36 @@ func newPromoteCommand(cli *client.Cli) *cobra.Command {
 //Promote a deployment on an environment and print the state of each deployment on the console
 func runPromote(cmd *cobra.Command, cli *client.Cli, args []string) {
 
+	if err := validateReportFlags(promoteReports); err != nil {
+		log.Fatal(err)
+	}
+
+	//Explain the selection without deploying
+	if explainSelection {
+		decisions, err := client.ExplainPromoteSelection(cli, &commandOptionsPromote)
+		if err != nil {
+			log.Fatal("Error Explain Selection: ", err)
+		}
+		printSelection(cmd, decisions)
+		return
+	}
+
 	//Ask user for confirmation
 	msg := fmt.Sprintf("Do you really want to start deployments on environment: %s", commandOptionsPromote.Environment)
-	if commandOptionsPromote.Silent || AskYesNo(msg) {
+	if commandOptionsPromote.Silent || cmdutil.AskYesNo(msg) {
 
 		//Promote deployment
+		start := time.Now()
 		deployments, err := client.PromoteDeployments(cli, &commandOptionsPromote)
+
+		//Write reports, also on errors
+		run := deploymentRun{Name: "promote " + commandOptionsPromote.Environment, Environment: commandOptionsPromote.Environment, Deployments: deployments, Err: err, Duration: time.Since(start)}
+		if reportErr := writeReports(promoteReports, &run); reportErr != nil {
+			log.Print(reportErr)
+		}
+
 		if err != nil {
-			log.Fatal("Error Promote Deployment: ", err)
+			log.Fatal("Error Promote Deployment: ", cmdutil.WithPolicyHint(err))
 		}
 		success := true
 
@@ -82,7 +125,7 @@ func runPromote(cmd *cobra.Command, cli *client.Cli, args []string) {
 			PrintDeployment(cmd, &deployment)
 
 			//Check success
-			success = success && (deployment.State == client.DeploymentStateSuccess || deployment.State == client.DeploymentStateRejected)
+			success = success && (deployment.State == client.DeploymentStateSuccess || deployment.State == client.DeploymentStateRejected || deployment.State == client.DeploymentStateExcluded)
 
 		}
 
@@ -93,3 +136,14 @@ func runPromote(cmd *cobra.Command, cli *client.Cli, args []string) {
 
 	}
 }
+
+//printSelection prints why each appServer is promoted or not
+func printSelection(cmd *cobra.Command, decisions []client.SelectionDecision) {
+	for _, decision := range decisions {
+		selected := "excluded"
+		if decision.Included {
+			selected = "included"
+		}
+		cmd.Printf("%-30s %-20s %-9s %s\n", decision.AppServerName, decision.RuntimeName, selected, decision.Reason)
+	}
+}
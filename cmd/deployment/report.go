@@ -0,0 +1,147 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/report"
+)
+
+//Report formats of the flag --report format=path
+const (
+	reportFormatJUnit    = "junit"
+	reportFormatMarkdown = "markdown"
+)
+
+//reportFlagUsage is the usage of the flag --report
+const reportFlagUsage = "Write a report of the deployments 'junit=path' or 'markdown=path', can be repeated"
+
+//deploymentRun is the result of a create or promote run used for the reports
+type deploymentRun struct {
+	Name        string             //Name of the run, example: "promote Y"
+	Environment string             //Target environment
+	Deployments client.Deployments //Created deployments
+	Err         error              //Error of the run, *client.CreateDeploymentError if creating a deployment failed
+	Duration    time.Duration      //Duration of the run until the state of the deployments was known
+}
+
+//validateReportFlags checks the flag values 'format=path' before deploying
+func validateReportFlags(values []string) error {
+	for _, value := range values {
+		format, path, found := strings.Cut(value, "=")
+		if !found || path == "" || (format != reportFormatJUnit && format != reportFormatMarkdown) {
+			return fmt.Errorf("invalid report %q, want 'junit=path' or 'markdown=path'", value)
+		}
+	}
+	return nil
+}
+
+//writeReports writes the reports given by the flag values 'format=path'
+func writeReports(values []string, run *deploymentRun) error {
+	for _, value := range values {
+		format, path, _ := strings.Cut(value, "=")
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("Couldn't create report: %v", err)
+		}
+		switch format {
+		case reportFormatJUnit:
+			err = report.WriteJUnit(file, run.testSuite())
+		case reportFormatMarkdown:
+			err = run.writeMarkdown(file)
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("Couldn't write report %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+//testSuite returns the run as JUnit test suite with a test case per app server
+//Failed deployments are failures, creation errors are errors and rejected (node not active) or excluded deployments are skipped
+//The time of a test case is the duration of its deployment, the deployments run in parallel and the time of the suite is the duration of the run
+func (run *deploymentRun) testSuite() report.TestSuite {
+	suite := report.TestSuite{Name: run.Name, Timestamp: time.Now().Format("2006-01-02T15:04:05")}
+	className := "deployment." + run.Environment
+
+	for _, deployment := range run.Deployments {
+		testCase := report.TestCase{Name: deployment.AppServerName, ClassName: className, Time: deployment.Duration.Seconds(), SystemOut: deploymentSummary(&deployment)}
+		switch deployment.State {
+		case client.DeploymentStateFailed:
+			testCase.Failure = &report.Message{Message: "Deployment failed with state: failed", Type: string(deployment.State), Text: testCase.SystemOut}
+		case client.DeploymentStateRejected:
+			testCase.Skipped = &report.Message{Message: "Deployment rejected, node not active"}
+		case client.DeploymentStateExcluded:
+			testCase.Skipped = &report.Message{Message: "Not promoted: " + deployment.StatusMessage}
+		}
+		suite.AddTestCase(testCase)
+	}
+
+	if run.Err != nil {
+		testCase := report.TestCase{Name: run.Name, ClassName: className}
+		var createErr *client.CreateDeploymentError
+		if errors.As(run.Err, &createErr) {
+			testCase.Name = createErr.AppServerName
+		}
+		testCase.Error = &report.Message{Message: run.Err.Error(), Type: "error"}
+		suite.AddTestCase(testCase)
+	}
+	suite.Time = run.Duration.Seconds()
+	return suite
+}
+
+//writeMarkdown writes the run as Markdown table with a row per app server
+//The duration of a deployment is empty, if it wasn't waited for
+func (run *deploymentRun) writeMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "## %s\n\n", run.Name); err != nil {
+		return err
+	}
+	table := report.MarkdownTable{Header: []string{"App server", "Environment", "State", "Duration", "Release", "Versions", "Message"}}
+	for _, deployment := range run.Deployments {
+		message := ""
+		switch deployment.State {
+		case client.DeploymentStateFailed:
+			message = "Deployment failed"
+		case client.DeploymentStateRejected:
+			message = "Deployment rejected, node not active"
+		case client.DeploymentStateExcluded:
+			message = "Not promoted: " + deployment.StatusMessage
+		}
+		duration := ""
+		if deployment.Duration > 0 {
+			duration = deployment.Duration.Round(time.Second).String()
+		}
+		table.AddRow(deployment.AppServerName, deployment.EnvironmentName, string(deployment.State), duration, deployment.ReleaseName, versions(&deployment), message)
+	}
+	if run.Err != nil {
+		name := run.Name
+		var createErr *client.CreateDeploymentError
+		if errors.As(run.Err, &createErr) {
+			name = createErr.AppServerName
+		}
+		table.AddRow(name, run.Environment, "error", "", "", "", run.Err.Error())
+	}
+	return table.Write(w)
+}
+
+//deploymentSummary returns the state, release and versions of a deployment
+func deploymentSummary(deployment *client.DeploymentResponse) string {
+	return fmt.Sprintf("state: %s\nrelease: %s\n%s", deployment.State, deployment.ReleaseName, versions(deployment))
+}
+
+//versions returns the applications with version, one per line
+func versions(deployment *client.DeploymentResponse) string {
+	versions := []string{}
+	for _, app := range deployment.AppsWithVersion {
+		versions = append(versions, app.ApplicationName+" "+app.Version)
+	}
+	return strings.Join(versions, "\n")
+}
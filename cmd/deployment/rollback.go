@@ -0,0 +1,111 @@
+package deployment
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/util"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	deploymentRollbackLong = `	Rollback application servers on an environment to the previous successful deployment.
+	The previous successful deployment is the most recent successful deployment with other versions than the latest deployment.
+	The rollback deploys its release and application versions again.`
+
+	//Example command description
+	deploymentRollbackExample = `	# Rollback an application server
+	liimactl deployment rollback --appServer=test_application --environment=P --wait
+	# Show only what would be rolled back
+	liimactl deployment rollback --appServer=test_application,aps_bau --environment=P --dryRun
+	# Rollback all application servers of an environment except some
+	liimactl deployment rollback --all --environment=P --blacklistAppServer="aps_bau_kube,vvn" --blacklistRuntime="re:^kube_.*"`
+
+	//Flags of the command
+	commandOptionsRollback client.CommandOptionsRollbackDeployments
+	rollbackSilent         bool
+	rollbackDryRun         bool
+)
+
+//newRollbackCommand is a command to rollback deployments on an environment
+func newRollbackCommand(cli *client.Cli) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "rollback [flags] ",
+		Short:   "Rollback to the previous successful deployment",
+		Long:    deploymentRollbackLong,
+		Example: deploymentRollbackExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runRollback(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&commandOptionsRollback.AppServer, "appServer", "a", []string{}, "Application Server Name")
+	cmd.Flags().StringVarP(&commandOptionsRollback.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().BoolVar(&commandOptionsRollback.AllAppServers, "all", false, "Rollback all application servers of the environment (exclusive blacklist)")
+	cmd.Flags().StringSliceVarP(&commandOptionsRollback.BlacklistAppServer, "blacklistAppServer", "b", []string{}, "Blacklist with all appServer, which should not be rolled back, patterns: 'aps_*' or 're:^aps_.*'")
+	cmd.Flags().StringSliceVarP(&commandOptionsRollback.BlacklistRuntime, "blacklistRuntime", "r", []string{}, "Blacklist with all runtimes, which should not be rolled back, patterns: 'kube_*' or 're:^kube_.*'")
+	cmd.Flags().StringVarP(&commandOptionsRollback.DeploymentDate, "date", "d", "", "Deployment Date 'YYYY-MM-DD hh:mm', 'DD.MM.YYYY hh:mm', ISO-8601, '+2h' or 'tomorrow 06:00'")
+	cmd.Flags().StringVar(&commandOptionsRollback.TimeZone, "timezone", "", "Timezone of the deployment date, example: 'Europe/Zurich' (default local timezone)")
+	cmd.Flags().BoolVarP(&commandOptionsRollback.ExecuteShakedownTest, "executeShakeDownTest", "s", false, "Run Shakedowntest after the deployment")
+	cmd.Flags().BoolVarP(&commandOptionsRollback.Wait, "wait", "w", false, "Wait maxWaitTime until the deployment success or failed")
+	cmd.Flags().IntVarP(&commandOptionsRollback.MaxWaitTime, "maxWaitTime", "t", 600, "Max Wait time [seconds] until the deployment success or failed")
+	cmd.Flags().BoolVarP(&rollbackSilent, "silent", "c", false, "Silent mode, no confirmation of the rollback")
+	cmd.Flags().BoolVar(&rollbackDryRun, "dryRun", false, "Show only what would be rolled back")
+	cmdutil.AddPolicyFlags(cmd.Flags(), &commandOptionsRollback.PolicyOptions)
+
+	return cmd
+}
+
+//Rollback deployments on an environment and print the state of each deployment on the console
+func runRollback(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	plans, err := client.PlanRollback(cli, &commandOptionsRollback)
+	if err != nil {
+		log.Fatal("Error Rollback Deployment: ", err)
+	}
+	if len(plans) == 0 {
+		cmd.Println("Nothing to roll back")
+		return
+	}
+
+	//Show what will change
+	for _, plan := range plans {
+		PrintRollbackPlan(cmd, &plan)
+	}
+	if rollbackDryRun {
+		return
+	}
+
+	//Ask user for confirmation
+	msg := fmt.Sprintf("Do you really want to rollback %d application server(s) on environment: %s", len(plans), commandOptionsRollback.Environment)
+	if !rollbackSilent && !cmdutil.AskYesNo(msg) {
+		return
+	}
+
+	deployments, err := client.RollbackDeployments(cli, &commandOptionsRollback, plans)
+	if err != nil {
+		log.Fatal("Error Rollback Deployment: ", cmdutil.WithPolicyHint(err))
+	}
+	success := true
+	for _, deployment := range deployments {
+		PrintDeployment(cmd, &deployment)
+		success = success && deployment.State != client.DeploymentStateFailed
+	}
+
+	//Write failed -> return code = 1 with log.Fatal
+	if !success {
+		log.Fatal("Rollback failed, not all deployments are successfully")
+	}
+}
+
+//PrintRollbackPlan prints out the version changes of a rollback
+func PrintRollbackPlan(cmd *cobra.Command, plan *client.RollbackPlan) {
+	cmd.Println("------")
+	cmd.Printf("%s %s %s -> %s %s\n", plan.AppServerName, plan.Latest.State, plan.Latest.DeploymentTime().Format(client.DisplayDateTimeFormat), plan.Target.DeploymentTime().Format(client.DisplayDateTimeFormat), plan.Target.ReleaseName)
+	for _, change := range plan.Changes {
+		cmd.Printf("%s %s -> %s\n", change.ApplicationName, util.VersionOrNone(change.OldVersion), util.VersionOrNone(change.NewVersion))
+	}
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	diffLong = `	Compare the latest successful deployments of each application server on two environments.
+	The differences are shown as changes of a promotion from the environment --from to the environment --to:
+	added (only on --from), removed (only on --to), upgraded, downgraded, changed or unchanged.`
+
+	//Example command description
+	diffExample = `	# Show the differences before promoting from B to Y
+	liimactl diff --from=B --to=Y
+	# Compare only some application servers and print JSON
+	liimactl diff --from=B --to=Y --appServer=aps_bau,vvn --output=json
+	# Exit with return code 1 if the environments differ
+	liimactl diff --from=B --to=Y --exitCode`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsDiffEnvironments
+	output         string
+	showAll        bool
+	exitCode       bool
+)
+
+//NewDiffCmd is a command to compare the versions of two environments
+func NewDiffCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "diff [flags] ",
+		Short:   "Compare the deployed versions of two environments",
+		Long:    diffLong,
+		Example: diffExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiff(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptions.FromEnvironment, "from", "f", "", "Environment with the versions to promote")
+	cmd.Flags().StringVarP(&commandOptions.ToEnvironment, "to", "t", "", "Environment to compare with")
+	cmd.Flags().StringSliceVarP(&commandOptions.AppServer, "appServer", "a", []string{}, "Application server name, all if not set")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
+	cmd.Flags().BoolVar(&showAll, "all", false, "Show also the unchanged application servers")
+	cmd.Flags().BoolVar(&exitCode, "exitCode", false, "Exit with return code 1 if the environments differ")
+
+	return cmd
+}
+
+//Compare the environments and print the differences on the console
+func runDiff(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	diff, err := client.DiffEnvironments(cli, &commandOptions)
+	if err != nil {
+		log.Fatal("Error Diff Environments: ", err)
+	}
+
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		//JSON to stdout, used in pipes
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "text":
+		PrintEnvironmentDiff(cmd, diff, showAll)
+	default:
+		log.Fatalf("Unknown output format: %s", output)
+	}
+
+	//Return code = 1 with log.Fatal, used in batch jobs
+	if exitCode && diff.HasDifferences() {
+		log.Fatalf("Environments %s and %s differ", diff.FromEnvironment, diff.ToEnvironment)
+	}
+}
+
+//PrintEnvironmentDiff prints out the differences per app server and application
+func PrintEnvironmentDiff(cmd *cobra.Command, diff *client.EnvironmentDiff, showAll bool) {
+	for _, appServer := range diff.AppServers {
+		if appServer.State == client.DiffStateUnchanged && !showAll {
+			continue
+		}
+		cmd.Println("------")
+		cmd.Printf("%-40s %-20s %-20s %s\n", appServer.AppServerName, versionOrDash(appServer.FromRelease), versionOrDash(appServer.ToRelease), appServer.State)
+		for _, application := range appServer.Applications {
+			cmd.Printf("%-40s %-20s %-20s %s\n", application.ApplicationName, versionOrDash(application.FromVersion), versionOrDash(application.ToVersion), application.State)
+		}
+	}
+	if !diff.HasDifferences() {
+		cmd.Printf("No differences between %s and %s\n", diff.FromEnvironment, diff.ToEnvironment)
+	}
+}
+
+//versionOrDash returns a dash for a missing version
+func versionOrDash(version string) string {
+	if version == "" {
+		return "-"
+	}
+	return version
+}
@@ -0,0 +1,180 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/report"
+	"github.com/liimaorg/liimactl/client/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	driftLong = `	Report the drift of the application servers on an environment without deploying.
+	Reported are application servers whose latest deployment failed, which have a newer non-successful deployment than the last success
+	and whose versions differ from the expected versions (file in the format of "liimactl snapshot" or the versions of another environment).
+	The versions are only compared for the application servers listed in the expected versions.`
+
+	//Example command description
+	driftExample = `	# Report failed and unsuccessful deployments
+	liimactl drift --environment=P
+	# Report also the differences to expected versions as JUnit XML
+	liimactl drift --environment=P --expected=versions.yaml --output=junit > drift.xml
+	# Report the differences to another environment as Markdown and exit with return code 1 on findings
+	liimactl drift --environment=P --againstEnvironment=B --output=markdown --exitCode`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsDriftReport
+	expectedFile   string
+	output         string
+	exitCode       bool
+)
+
+//NewDriftCmd is a command to report the drift of an environment
+func NewDriftCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "drift [flags] ",
+		Short:   "Report the drift of an environment",
+		Long:    driftLong,
+		Example: driftExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDrift(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptions.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().StringVarP(&commandOptions.AgainstEnvironment, "againstEnvironment", "f", "", "Expect the versions of this environment")
+	cmd.Flags().StringVarP(&expectedFile, "expected", "x", "", "File with the expected versions")
+	cmd.Flags().StringSliceVarP(&commandOptions.AppServer, "appServer", "a", []string{}, "Application server name, all if not set")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text, json, junit or markdown")
+	cmd.Flags().BoolVar(&exitCode, "exitCode", false, "Exit with return code 1 if there are findings")
+
+	return cmd
+}
+
+//Create the drift report and print it on the console
+func runDrift(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	if expectedFile != "" && commandOptions.AgainstEnvironment != "" {
+		log.Fatal("--expected can't be combined with --againstEnvironment")
+	}
+	var expected *client.Snapshot
+	if expectedFile != "" {
+		file, err := os.Open(expectedFile)
+		if err != nil {
+			log.Fatal("Couldn't open expected versions: ", err)
+		}
+		expected, err = client.ReadSnapshot(file)
+		file.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	driftReport, err := client.CreateDriftReport(cli, &commandOptions, expected)
+	if err != nil {
+		log.Fatal("Error Drift Report: ", err)
+	}
+
+	//Reports to stdout, used in pipes
+	switch output {
+	case "text":
+		for _, appServer := range driftReport.AppServers {
+			PrintAppServerDrift(cmd, &appServer)
+		}
+	case "json":
+		data, err := json.MarshalIndent(driftReport, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "junit":
+		err = report.WriteJUnit(cmd.OutOrStdout(), JUnitTestSuite(driftReport))
+	case "markdown":
+		err = WriteMarkdown(cmd.OutOrStdout(), driftReport)
+	default:
+		log.Fatalf("Unknown output format: %s", output)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	//Return code = 1 with log.Fatal, used in batch jobs
+	if exitCode && driftReport.HasFindings() {
+		log.Fatalf("Drift on environment %s", driftReport.Environment)
+	}
+}
+
+//PrintAppServerDrift prints out the findings and version changes of an app server
+func PrintAppServerDrift(cmd *cobra.Command, appServer *client.AppServerDriftReport) {
+	cmd.Println("------")
+	cmd.Printf("%s %s %s\n", appServer.AppServerName, latestState(appServer), findings(appServer))
+	for _, change := range appServer.Changes {
+		cmd.Printf("%s %s -> %s\n", change.ApplicationName, util.VersionOrNone(change.OldVersion), util.VersionOrNone(change.NewVersion))
+	}
+}
+
+//JUnitTestSuite returns the drift report as JUnit test suite, each app server is a test case failing on findings
+func JUnitTestSuite(driftReport *client.DriftReport) report.TestSuite {
+	suite := report.TestSuite{Name: "drift " + driftReport.Environment}
+	for _, appServer := range driftReport.AppServers {
+		testCase := report.TestCase{Name: appServer.AppServerName, ClassName: "drift." + driftReport.Environment}
+		if len(appServer.Findings) > 0 {
+			testCase.Failure = &report.Message{Message: findings(&appServer), Type: string(appServer.Findings[0]), Text: changes(&appServer)}
+		}
+		suite.AddTestCase(testCase)
+	}
+	return suite
+}
+
+//WriteMarkdown writes the drift report as Markdown table
+func WriteMarkdown(w io.Writer, driftReport *client.DriftReport) error {
+	title := "Drift on environment " + driftReport.Environment
+	if driftReport.Expected != "" {
+		title += " against " + driftReport.Expected
+	}
+	if _, err := fmt.Fprintf(w, "## %s\n\n", title); err != nil {
+		return err
+	}
+	table := report.MarkdownTable{Header: []string{"App server", "Latest state", "Findings", "Version changes"}}
+	for _, appServer := range driftReport.AppServers {
+		table.AddRow(appServer.AppServerName, latestState(&appServer), findings(&appServer), changes(&appServer))
+	}
+	return table.Write(w)
+}
+
+//latestState returns the state of the latest deployment
+func latestState(appServer *client.AppServerDriftReport) string {
+	if appServer.Latest == nil {
+		return "notDeployed"
+	}
+	return string(appServer.Latest.State)
+}
+
+//findings returns the findings separated by comma, "ok" if no findings
+func findings(appServer *client.AppServerDriftReport) string {
+	if len(appServer.Findings) == 0 {
+		return "ok"
+	}
+	findings := []string{}
+	for _, finding := range appServer.Findings {
+		findings = append(findings, string(finding))
+	}
+	return strings.Join(findings, ",")
+}
+
+//changes returns the version changes, one per line
+func changes(appServer *client.AppServerDriftReport) string {
+	changes := []string{}
+	for _, change := range appServer.Changes {
+		changes = append(changes, fmt.Sprintf("%s %s -> %s", change.ApplicationName, util.VersionOrNone(change.OldVersion), util.VersionOrNone(change.NewVersion)))
+	}
+	return strings.Join(changes, "\n")
+}
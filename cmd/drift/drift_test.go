@@ -0,0 +1,70 @@
+package drift
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the command "drift"
+func TestNewDriftCmd(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"--environment=P"}, "------\nTest success ok\n"},
+		{"Test2", []string{"--environment=P", "--againstEnvironment=B", "--output=json"}, "{\n  \"environment\": \"P\",\n  \"expected\": \"environment B\""},
+		{"Test3", []string{"--environment=P", "--output=junit"}, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<testsuites>\n  <testsuite name=\"drift P\" tests=\"1\" failures=\"0\""},
+		{"Test4", []string{"--environment=P", "--output=markdown"}, "## Drift on environment P\n\n| App server | Latest state | Findings | Version changes |\n| --- | --- | --- | --- |\n| Test | success | ok |  |\n"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewDriftCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
@@ -0,0 +1,19 @@
+package environment
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+//NewEnvironmentCmd is a command to list the environments
+func NewEnvironmentCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "environment COMMAND",
+		Short: "List environments",
+	}
+
+	cmd.AddCommand(newListCommand(cli))
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package environment
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	environmentListLong = `	List the environments grouped by their parent domain (domain, name, full name and id).
+	The commands accept the name or the full name of an environment.`
+
+	//Example command description
+	environmentListExample = `	# List all environments
+	liimactl environment list`
+)
+
+//newListCommand is a command to list the environments
+func newListCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "list [flags] ",
+		Short:   "List environments",
+		Long:    environmentListLong,
+		Example: environmentListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(cmd, cli, args)
+		},
+	}
+
+	return cmd
+}
+
+//List the environments grouped by domain and print them on the console
+func runList(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	environments, err := client.GetEnvironments(cli)
+	if err != nil {
+		log.Fatalf("Couldn't get environments: %v", err)
+	}
+
+	//Print result
+	for _, domain := range environments.Domains() {
+		for _, environment := range environments {
+			if environment.Parent == domain {
+				cmd.Printf("%-15s %-15s %-25s %d\n", environment.Parent, environment.Name, environment.NameAlias, environment.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	generateLong = `	Generate the configuration files of an app server on an environment with the template engine of Liima, without deploying.
+	The files are listed or written to the output directory, each node in its own directory.
+	With --diff-with-environment the files are generated for both environments and shown as unified diff,
+	the nodes are paired by their name without the environment suffix (node_t on T with node_p on P).`
+
+	//Example command description
+	generateExample = `	# List the generated files of an app server on environment T
+	liimactl generate --appServer=aps_bau --environment=T
+	# Write the generated files of a release to a directory
+	liimactl generate --appServer=aps_bau --environment=T --release=RL-19.04 --out=generated/
+	# Show the differences of the generated files between the environments T and P
+	liimactl generate --appServer=aps_bau --environment=T --diff-with-environment=P`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsGenerate
+	out            string
+)
+
+//NewGenerateCmd is a command to preview the generated configuration files of an app server
+func NewGenerateCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "generate [flags] ",
+		Short:   "Preview the generated configuration files of an app server",
+		Long:    generateLong,
+		Example: generateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runGenerate(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptions.AppServer, "appServer", "a", "", "Application server name")
+	cmd.Flags().StringVarP(&commandOptions.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().StringVarP(&commandOptions.Release, "release", "r", "", "Release of the app server, the last release if not set")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Output directory of the generated files, with --diff-with-environment a directory per environment")
+	cmd.Flags().StringVar(&commandOptions.DiffWithEnvironment, "diff-with-environment", "", "Generate also for this environment and show the differences")
+
+	return cmd
+}
+
+//Generate the files of the app server and print them or their differences on the console
+func runGenerate(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	results, err := client.Generate(cli, &commandOptions)
+	if err != nil {
+		log.Fatal("Error Generate: ", err)
+	}
+
+	success := true
+	for _, result := range results {
+		for _, generationError := range result.Errors {
+			log.Printf("Generation error on environment %s: %s", result.Environment, generationError)
+			success = false
+		}
+
+		//Write the files
+		if out != "" {
+			dir := out
+			if len(results) > 1 {
+				dir = filepath.Join(out, result.Environment)
+			}
+			if err := client.WriteGeneratedFiles(result, dir); err != nil {
+				log.Fatal(err)
+			}
+			cmd.Printf("Wrote %d files of environment %s to %s\n", len(result.Files), result.Environment, dir)
+		}
+	}
+
+	//Print the differences or the files
+	if len(results) > 1 {
+		diff := client.DiffGenerationResults(results[0], results[1])
+		if diff == "" {
+			cmd.Printf("No differences between environment %s and %s\n", results[0].Environment, results[1].Environment)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), diff)
+	} else if out == "" {
+		for _, file := range results[0].Files {
+			cmd.Printf("%-20s %-50s %d\n", file.Node, file.Path, len(file.Content))
+		}
+	}
+
+	//Write failed, if the template engine reported errors -> return code = 1 with log.Fatal
+	if !success {
+		log.Fatal("Generation failed, the template engine reported errors")
+	}
+}
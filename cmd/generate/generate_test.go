@@ -0,0 +1,79 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the command "generate"
+func TestNewGenerateCmd(t *testing.T) {
+
+	dir := t.TempDir()
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"--appServer=Test", "--environment=T"}, fmt.Sprintf("%-20s %-50s %d\n", "node_t", "bin/setenv.sh", 47)},
+		{"Test2", []string{"--appServer=Test", "--environment=T", "--release=RL-18.10", "--out=" + dir}, "Wrote 2 files of environment T to " + dir + "\n"},
+		{"Test3", []string{"--appServer=Test", "--environment=T", "--diff-with-environment=PROD-CH"}, "--- T/node_t/bin/setenv.sh\n+++ P/node_p/bin/setenv.sh\n@@ -1,3 +1,3 @@\n #!/bin/sh\n-JAVA_OPTS=-Xmx1024m\n+JAVA_OPTS=-Xmx2048m\n export JAVA_OPTS\n"},
+		{"Test4", []string{"--appServer=Test", "--environment=T", "--diff-with-environment=Test"}, "No differences between environment T and T\n"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewGenerateCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	//Check written file
+	if content, err := os.ReadFile(filepath.Join(dir, "node_t", "conf", "app.properties")); err != nil || string(content) != "app.name=testapp\n" {
+		t.Errorf("Generated file = %q, %v", content, err)
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"log"
+	"os"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	graphLong = `	Write the dependency graph of the app servers on an environment: the resources related to the app servers
+	(consumed and provided relations) and the hosts of the app servers.
+	The graph starts with the releases of the latest successful deployments of the app servers on the environment.
+	Formats: dot (Graphviz), mermaid or json.`
+
+	//Example command description
+	graphExample = `	# Render the graph of environment T with Graphviz
+	liimactl graph --environment=T | dot -Tsvg > graph.svg
+	# Write the graph of the app servers aps_* as mermaid flowchart
+	liimactl graph --environment=T --appServer="aps_*" --format=mermaid --out=graph.mmd`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsGraph
+	out            string
+)
+
+//NewGraphCmd is a command to write the dependency graph of an environment
+func NewGraphCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "graph [flags] ",
+		Short:   "Write the dependency graph of an environment",
+		Long:    graphLong,
+		Example: graphExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runGraph(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptions.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().StringSliceVarP(&commandOptions.AppServer, "appServer", "a", []string{}, "App servers of the graph, all if not set, patterns: 'aps_*' or 're:^aps_.*'")
+	cmd.Flags().StringVarP(&commandOptions.Format, "format", "f", client.GraphFormatDot, "Format: dot, mermaid or json")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Output file, stdout if not set")
+
+	return cmd
+}
+
+//Write the graph given by the flags to the output file or stdout
+func runGraph(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	graph, err := client.GetGraph(cli, &commandOptions)
+	if err != nil {
+		log.Fatal("Error Graph: ", err)
+	}
+
+	//Write result
+	w := cmd.OutOrStdout()
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			log.Fatalf("Couldn't create graph file: %v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := client.WriteGraph(w, graph, commandOptions.Format); err != nil {
+		log.Fatal(err)
+	}
+}
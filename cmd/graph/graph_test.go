@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the command "graph"
+func TestNewGraphCmd(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"--environment=T"}, "digraph \"T\" {\n  rankdir=LR;\n  \"Test@RL-19.04\" [label=\"Test\\nRL-19.04\", shape=box];\n  \"testapp@RL-19.04\" [label=\"testapp\\nRL-19.04\", shape=ellipse];\n  \"node01@RL-18.10\" [label=\"node01\\nRL-18.10\", shape=component];\n  \"Test@RL-19.04\" -> \"testapp@RL-19.04\" [label=\"CONSUMED\"];\n"},
+		{"Test2", []string{"--environment=T", "--format=mermaid"}, "graph LR\n  n0[\"Test<br/>RL-19.04\"]\n  n1(\"testapp<br/>RL-19.04\")\n  n2[[\"node01<br/>RL-18.10\"]]\n  n0 -->|CONSUMED| n1\n  n0 -->|CONSUMED| n2\n"},
+		{"Test3", []string{"--environment=T", "--appServer=Test", "--format=json"}, "{\n  \"environment\": \"T\",\n  \"nodes\": [\n    {\n      \"id\": \"Test@RL-19.04\","},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewGraphCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
@@ -0,0 +1,155 @@
+package matrix
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	matrixLong = `	Show the latest successful deployment of each application server (rows) on each environment (columns)
+	with the versions of the applications. A cell differing from the cell of the previous environment is highlighted:
+	marked with * (text and csv), bold (markdown) or with a background color (html).`
+
+	//Example command description
+	matrixExample = `	# Show the versions of all application servers on all environments
+	liimactl matrix
+	# Show some application servers on the environments in the order of the promotion
+	liimactl matrix --environment=D,T,I,P --appServer=aps_bau,vvn
+	# Write a page for the release meeting
+	liimactl matrix --environment=D,T,I,P --output=html > matrix.html`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsVersionMatrix
+	output         string
+)
+
+//NewMatrixCmd is a command to show the versions of the app servers on the environments
+func NewMatrixCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "matrix [flags] ",
+		Short:   "Show the deployed versions of the app servers on the environments",
+		Long:    matrixLong,
+		Example: matrixExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runMatrix(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&commandOptions.Environment, "environment", "e", []string{}, "Environments in the order of the columns, all if not set")
+	cmd.Flags().StringSliceVarP(&commandOptions.AppServer, "appServer", "a", []string{}, "Application server name, all if not set")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text, json, csv, html or markdown")
+
+	return cmd
+}
+
+//Get the version matrix and print it on the console
+func runMatrix(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	matrix, err := client.GetVersionMatrix(cli, &commandOptions)
+	if err != nil {
+		log.Fatalf("Couldn't get version matrix: %v", err)
+	}
+
+	//Reports to stdout, used in pipes
+	w := cmd.OutOrStdout()
+	switch output {
+	case "text":
+		table := report.TextTable{Header: header(matrix)}
+		for _, row := range matrix.AppServers {
+			table.AddRow(markedCells(&row)...)
+		}
+		err = table.Write(w)
+	case "json":
+		data, err := json.MarshalIndent(matrix, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "csv":
+		err = writeCSV(w, matrix)
+	case "html":
+		err = writeHTML(w, matrix)
+	case "markdown":
+		err = writeMarkdown(w, matrix)
+	default:
+		log.Fatalf("Unknown output format: %s", output)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+//header returns the header row with the environments
+func header(matrix *client.VersionMatrix) []string {
+	return append([]string{"App server"}, matrix.Environments...)
+}
+
+//markedCells returns the app server and the versions of the row, changed cells are marked with *
+func markedCells(row *client.VersionMatrixRow) []string {
+	cells := []string{row.AppServerName}
+	for _, cell := range row.Cells {
+		text := cell.VersionsText(", ")
+		if cell.Changed {
+			text += " *"
+		}
+		cells = append(cells, text)
+	}
+	return cells
+}
+
+//writeCSV writes the matrix as CSV with a header row
+func writeCSV(w io.Writer, matrix *client.VersionMatrix) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header(matrix)); err != nil {
+		return err
+	}
+	for _, row := range matrix.AppServers {
+		if err := writer.Write(markedCells(&row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+//writeHTML writes the matrix as HTML page, changed cells are highlighted
+func writeHTML(w io.Writer, matrix *client.VersionMatrix) error {
+	table := report.HTMLTable{Title: "Versions", Header: header(matrix)}
+	for _, row := range matrix.AppServers {
+		cells := []report.HTMLCell{{Text: row.AppServerName}}
+		for _, cell := range row.Cells {
+			cells = append(cells, report.HTMLCell{Text: cell.VersionsText("\n"), Highlight: cell.Changed})
+		}
+		table.AddRow(cells...)
+	}
+	return table.Write(w)
+}
+
+//writeMarkdown writes the matrix as Markdown table, changed cells are bold
+func writeMarkdown(w io.Writer, matrix *client.VersionMatrix) error {
+	if _, err := fmt.Fprintf(w, "## Versions\n\n"); err != nil {
+		return err
+	}
+	table := report.MarkdownTable{Header: header(matrix)}
+	for _, row := range matrix.AppServers {
+		cells := []string{row.AppServerName}
+		for _, cell := range row.Cells {
+			text := cell.VersionsText("\n")
+			if cell.Changed {
+				text = "**" + text + "**"
+			}
+			cells = append(cells, text)
+		}
+		table.AddRow(cells...)
+	}
+	return table.Write(w)
+}
@@ -0,0 +1,71 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the command "matrix"
+func TestNewMatrixCmd(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"--environment=B,Y"}, "App server  B            Y\nTest        testapp 1.0  testapp 1.0\n"},
+		{"Test2", []string{"--environment=b,Test", "--output=csv"}, "App server,B,T\nTest,testapp 1.0,testapp 1.0\n"},
+		{"Test3", []string{"--environment=B,Y", "--output=markdown"}, "## Versions\n\n| App server | B | Y |\n| --- | --- | --- |\n| Test | testapp 1.0 | testapp 1.0 |\n"},
+		{"Test4", []string{"--environment=B", "--output=html"}, "<!DOCTYPE html>\n"},
+		{"Test5", []string{"--environment=B", "--output=json"}, "{\n  \"environments\": [\n    \"B\"\n  ]"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewMatrixCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
@@ -0,0 +1,73 @@
+package property
+
+import (
+	"log"
+	"os"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	propertyExportLong = `	Export the property values of a resource and its relations defined on each environment to a property file (YAML).
+	Inherited values (defined on a domain or globally) are only exported with --contexts, as entries with the domain or "Global" as environment.
+	The file can be imported with "property import".`
+
+	//Example command description
+	propertyExportExample = `	# Export the properties of an application server
+	liimactl property export --resource=aps_bau --release=RL-19.04 --out=aps_bau.yaml
+	# Export the properties of the environments T and P
+	liimactl property export --resource=aps_bau --environment=T,P
+	# Export also the values defined globally and on the domains of the environment T
+	liimactl property export --resource=aps_bau --environment=T --contexts`
+
+	//Flags of the command
+	commandOptionsExport client.CommandOptionsExportProperty
+	exportOut            string
+)
+
+//newExportCommand is a command to export properties
+func newExportCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "export [flags] ",
+		Short:   "Export properties to a property file",
+		Long:    propertyExportLong,
+		Example: propertyExportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runExport(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&commandOptionsExport.Resource, "resource", "", "Resource name")
+	cmd.Flags().StringVar(&commandOptionsExport.Release, "release", "", "Release of the resource, the last release if not set")
+	cmd.Flags().StringSliceVarP(&commandOptionsExport.Environment, "environment", "e", []string{}, "Environments, all if not set")
+	cmd.Flags().BoolVar(&commandOptionsExport.Contexts, "contexts", false, "Also export the values defined globally and on the domains of the environments")
+	cmd.Flags().StringVarP(&exportOut, "out", "o", "", "Property file, stdout if not set")
+
+	return cmd
+}
+
+//Export the properties given by the flags to the property file or stdout
+func runExport(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	entries, err := client.ExportProperties(cli, &commandOptionsExport)
+	if err != nil {
+		log.Fatalf("Couldn't export properties: %v", err)
+	}
+
+	//Write result
+	out := cmd.OutOrStdout()
+	if exportOut != "" {
+		file, err := os.Create(exportOut)
+		if err != nil {
+			log.Fatalf("Couldn't create property file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := client.WritePropertyFile(out, entries); err != nil {
+		log.Fatal(err)
+	}
+}
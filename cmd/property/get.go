@@ -0,0 +1,67 @@
+package property
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	propertyGetLong = `	Show the effective property values of a resource or relation on an environment,
+	where the value is defined (the environment or the inherited domain or "Global" context) and whether it overrides an inherited value.
+	Liima doesn't return the resource type, resource or relation defining a property.`
+
+	//Example command description
+	propertyGetExample = `	# Show the properties of an application server on environment T
+	liimactl property get --resource=aps_bau --release=RL-19.04 --environment=T
+	# Show the jvm properties
+	liimactl property get --resource=aps_bau --environment=T --name="jvm.*"
+	# Show the properties of a relation
+	liimactl property get --resource=aps_bau --environment=T --relation=ch_mobi_app`
+
+	//Flags of the command
+	commandOptionsGet client.CommandOptionsGetProperty
+)
+
+//newGetCommand is a command to show properties
+func newGetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "get [flags] ",
+		Short:   "Show properties",
+		Long:    propertyGetLong,
+		Example: propertyGetExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runGet(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsGet.PropertyTarget)
+	cmd.Flags().StringSliceVarP(&commandOptionsGet.Name, "name", "n", []string{}, "Property name, patterns: 'jvm.*' or 're:^jvm\\.'")
+
+	return cmd
+}
+
+//Show the properties given by the flags and print them on the console
+func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	properties, err := client.GetProperties(cli, &commandOptionsGet)
+	if err != nil {
+		log.Fatalf("Couldn't get properties: %v", err)
+	}
+
+	//Print result
+	for _, property := range properties {
+		origin := "defined on environment"
+		if property.Inherited(commandOptionsGet.Environment) {
+			origin = "inherited from " + property.Context
+		}
+		overridden := ""
+		if property.Overridden() {
+			overridden = "overrides " + property.ReplacedValue
+		}
+		cmd.Printf("%-30s %-25s %-10s %-35s %s\n", property.Name, property.Value, property.Context, origin, overridden)
+	}
+}
@@ -0,0 +1,62 @@
+package property
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	propertyImportLong = `	Import a property file (YAML), for example written by "property export".
+	The changes against the server are shown and only the changed values are set. The flags are the defaults of the entries.
+	The environment of an entry can also be a domain or "Global", to set the values inherited by the environments.`
+
+	//Example command description
+	propertyImportExample = `	# Show the changes of a property file
+	liimactl property import -f aps_bau.yaml --dry-run
+	# Import a property file and unset the values missing in the file
+	liimactl property import -f aps_bau.yaml --prune`
+
+	//Flags of the command
+	commandOptionsImport client.CommandOptionsImportProperty
+)
+
+//newImportCommand is a command to import properties
+func newImportCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "import [flags] ",
+		Short:   "Import properties from a property file",
+		Long:    propertyImportLong,
+		Example: propertyImportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runImport(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsImport.PropertyTarget)
+	cmd.Flags().StringVarP(&commandOptionsImport.File, "file", "f", "", "Property file (YAML)")
+	cmd.Flags().BoolVar(&commandOptionsImport.DryRun, "dry-run", false, "Only show the changes, don't apply them")
+	cmd.Flags().BoolVar(&commandOptionsImport.Prune, "prune", false, "Unset the values defined on the environment but missing in the property file")
+
+	return cmd
+}
+
+//Import the property file given by the flags and print the changes on the console
+func runImport(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	changes, err := client.ImportProperties(cli, &commandOptionsImport)
+
+	//Print result, also the changes until an error
+	printChanges(cmd, changes)
+	if err != nil {
+		log.Fatalf("Couldn't import properties: %v", err)
+	}
+	if len(changes) == 0 {
+		cmd.Println("No changes")
+	} else if commandOptionsImport.DryRun {
+		cmd.Println("Dry run, no changes applied")
+	}
+}
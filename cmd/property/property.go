@@ -0,0 +1,47 @@
+package property
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+//NewPropertyCmd is a command to read and write properties of resources and relations
+func NewPropertyCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "property COMMAND",
+		Short: "Read and write properties of resources and relations",
+	}
+
+	cmd.AddCommand(newGetCommand(cli))
+	cmd.AddCommand(newSetCommand(cli))
+	cmd.AddCommand(newUnsetCommand(cli))
+	cmd.AddCommand(newExportCommand(cli))
+	cmd.AddCommand(newImportCommand(cli))
+
+	return cmd
+}
+
+//addTargetFlags adds the flags of the resource, release, environment and relation of the properties
+func addTargetFlags(flags *pflag.FlagSet, target *client.PropertyTarget) {
+	flags.StringVar(&target.Resource, "resource", "", "Resource name")
+	flags.StringVar(&target.Release, "release", "", "Release of the resource, the last release if not set")
+	flags.StringVarP(&target.Environment, "environment", "e", "", "Environment")
+	flags.StringVar(&target.Relation, "relation", "", "Related resource name, properties of the relation instead of the resource")
+}
+
+//printChanges prints the changes of the property values
+func printChanges(cmd *cobra.Command, changes []client.PropertyChange) {
+	for _, change := range changes {
+		resource := change.Resource
+		if change.Relation != "" {
+			resource += " -> " + change.Relation
+		}
+		value := change.Value
+		if change.Unset {
+			value = "(inherited)"
+		}
+		cmd.Printf("%s %s %s: %s %s -> %s\n", resource, change.Release, change.Environment, change.Name, change.PreviousValue, value)
+	}
+}
@@ -0,0 +1,83 @@
+package property
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the commands "property get", "property set" and "property unset"
+func TestNewPropertyCmd(t *testing.T) {
+
+	//Write property file
+	file, err := os.CreateTemp(t.TempDir(), "properties*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("- resource: Test\n  properties:\n    jvm.heap.max: 2048m\n    jvm.heap.min: 1g\n")
+	file.Close()
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"get", "--resource=Test", "--release=RL-19.04", "--environment=T"}, fmt.Sprintf("%-30s %-25s %-10s %-35s %s\n", "jvm.heap.max", "2048m", "T", "defined on environment", "overrides 1024m")},
+		{"Test2", []string{"get", "--resource=Test", "--environment=Test", "--name=*.min"}, fmt.Sprintf("%-30s %-25s %-10s %-35s %s\n", "jvm.heap.min", "512m", "Global", "inherited from Global", "")},
+		{"Test3", []string{"get", "--resource=Test", "--environment=T", "--relation=testapp"}, fmt.Sprintf("%-30s %-25s %-10s %-35s %s\n", "url", "http://testapp", "Test", "inherited from Test", "")},
+		{"Test4", []string{"set", "--resource=Test", "--environment=T", "jvm.heap.max=4096m", "jvm.heap.min=1024m"}, "Test RL-19.04 T: jvm.heap.max 2048m -> 4096m\nTest RL-19.04 T: jvm.heap.min 512m -> 1024m\n"},
+		{"Test5", []string{"unset", "--resource=Test", "--environment=T", "jvm.heap.max"}, "Test RL-19.04 T: jvm.heap.max 2048m -> (inherited)\n"},
+		{"Test6", []string{"export", "--resource=Test"}, "- resource: Test\n  release: RL-19.04\n  environment: T\n  properties:\n    jvm.heap.max: 2048m\n"},
+		{"Test7", []string{"import", "-f", file.Name(), "--environment=T", "--dry-run"}, "Test RL-19.04 T: jvm.heap.min 512m -> 1g\nDry run, no changes applied\n"},
+		{"Test8", []string{"export", "--resource=Test", "--environment=T", "--contexts"}, "- resource: Test\n  release: RL-19.04\n  environment: Global\n  properties:\n    jvm.heap.min: 512m\n- resource: Test\n  release: RL-19.04\n  environment: Test\n  relation: testapp\n  properties:\n    url: http://testapp\n- resource: Test\n  release: RL-19.04\n  environment: Test\n  relation: node01\n  properties:\n    url: http://testapp\n- resource: Test\n  release: RL-19.04\n  environment: T\n  properties:\n    jvm.heap.max: 2048m\n"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewPropertyCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
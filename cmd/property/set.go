@@ -0,0 +1,65 @@
+package property
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	propertySetLong = `	Set property values of a resource or relation on an environment.
+	A property file (YAML) sets the values of one or more resources, the flags are the defaults of its entries:
+	- resource: aps_bau
+	  release: RL-19.04
+	  environment: T
+	  properties:
+	    jvm.heap.max: 2048m
+	- resource: aps_bau
+	  environment: T
+	  relation: ch_mobi_app
+	  properties:
+	    url: http://ch_mobi_app`
+
+	//Example command description
+	propertySetExample = `	# Set the jvm heap size of an application server on environment T
+	liimactl property set --resource=aps_bau --release=RL-19.04 --environment=T jvm.heap.max=2048m jvm.heap.min=512m
+	# Set the values of a property file on environment T
+	liimactl property set --environment=T --file=properties.yaml`
+
+	//Flags of the command
+	commandOptionsSet client.CommandOptionsSetProperty
+)
+
+//newSetCommand is a command to set properties
+func newSetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "set [flags] [key=value...]",
+		Short:   "Set properties",
+		Long:    propertySetLong,
+		Example: propertySetExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSet(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsSet.PropertyTarget)
+	cmd.Flags().StringVarP(&commandOptionsSet.File, "file", "f", "", "Property file (YAML) with the values of one or more resources")
+
+	return cmd
+}
+
+//Set the properties given by the arguments and print the changes on the console
+func runSet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	commandOptionsSet.Values = args
+	changes, err := client.SetProperties(cli, &commandOptionsSet)
+
+	//Print result, also the changes until an error
+	printChanges(cmd, changes)
+	if err != nil {
+		log.Fatalf("Couldn't set properties: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package property
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	propertyUnsetLong = `	Unset property values of a resource or relation on an environment, the inherited values are used again.
+	Only values defined on the environment can be unset, inherited values are rejected.`
+
+	//Example command description
+	propertyUnsetExample = `	# Use the inherited jvm heap size of an application server on environment T
+	liimactl property unset --resource=aps_bau --release=RL-19.04 --environment=T jvm.heap.max`
+
+	//Flags of the command
+	commandOptionsUnset client.CommandOptionsUnsetProperty
+)
+
+//newUnsetCommand is a command to unset properties
+func newUnsetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "unset [flags] key...",
+		Short:   "Unset properties",
+		Long:    propertyUnsetLong,
+		Example: propertyUnsetExample,
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runUnset(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsUnset.PropertyTarget)
+
+	return cmd
+}
+
+//Unset the properties given by the arguments and print the changes on the console
+func runUnset(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	commandOptionsUnset.Name = args
+	changes, err := client.UnsetProperties(cli, &commandOptionsUnset)
+
+	//Print result, also the changes until an error
+	printChanges(cmd, changes)
+	if err != nil {
+		log.Fatalf("Couldn't unset properties: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/util"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	reconcileLong = `	Deploy the application servers which are not in their desired state.
+	The desired state file has the format of "liimactl snapshot", multiple environments are separated by "---".
+	Each application server is compared with its latest successful deployment, only the drift is deployed.
+	Application servers with a pending deployment of the desired state are skipped,
+	application servers with a failed deployment of the desired state only with --retryFailed.`
+
+	//Example command description
+	reconcileExample = `	# Show the drift without deploying
+	liimactl reconcile -f desired.yaml --dryRun
+	# Deploy the drift and wait for the deployments
+	liimactl reconcile -f desired.yaml --wait
+	# Reconcile every 10 minutes
+	liimactl reconcile -f desired.yaml --interval=10m`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsReconcile
+	desiredFiles   []string
+	interval       time.Duration
+	output         string
+)
+
+//NewReconcileCmd is a command to deploy the drift from a desired state
+func NewReconcileCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "reconcile [flags] ",
+		Short:   "Deploy the drift from a desired state file",
+		Long:    reconcileLong,
+		Example: reconcileExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runReconcile(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&desiredFiles, "file", "f", []string{}, "Desired state file")
+	cmd.Flags().BoolVar(&commandOptions.DryRun, "dryRun", false, "Only show the drift, don't deploy")
+	cmd.Flags().BoolVar(&commandOptions.RetryFailed, "retryFailed", false, "Deploy again, if the latest deployment of the desired state failed")
+	cmd.Flags().BoolVarP(&commandOptions.ExecuteShakedownTest, "executeShakeDownTest", "s", false, "Run Shakedowntest after the deployment")
+	cmd.Flags().BoolVarP(&commandOptions.Wait, "wait", "w", false, "Wait maxWaitTime until the deployment success or failed")
+	cmd.Flags().IntVarP(&commandOptions.MaxWaitTime, "maxWaitTime", "t", 600, "Max Wait time [seconds] until the deployment success or failed")
+	cmd.Flags().DurationVarP(&interval, "interval", "i", 0, "Reconcile continuously with the given interval, example: 10m")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format of the drift report: text or json")
+	cmdutil.AddPolicyFlags(cmd.Flags(), &commandOptions.PolicyOptions)
+
+	return cmd
+}
+
+//Reconcile once or continuously and print the drift report on the console
+func runReconcile(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	if interval <= 0 {
+		if err := reconcileOnce(cmd, cli); err != nil {
+			log.Fatal("Error Reconcile: ", cmdutil.WithPolicyHint(err))
+		}
+		return
+	}
+
+	for {
+		//Continue on errors, the next run may succeed
+		if err := reconcileOnce(cmd, cli); err != nil {
+			log.Print("Error Reconcile: ", cmdutil.WithPolicyHint(err))
+		}
+		time.Sleep(interval)
+	}
+}
+
+//reconcileOnce reads the desired state, reconciles and prints the drift report
+func reconcileOnce(cmd *cobra.Command, cli *client.Cli) error {
+
+	//Read the files on each run, the desired state may have changed
+	desiredState, err := readDesiredState(desiredFiles)
+	if err != nil {
+		return err
+	}
+
+	drifts, reconcileErr := client.Reconcile(cli, &commandOptions, desiredState)
+	if err := printDriftReport(cmd, drifts); err != nil {
+		return err
+	}
+	if reconcileErr != nil {
+		return reconcileErr
+	}
+
+	//Failed deployments are an error
+	for _, drift := range drifts {
+		if drift.Deployment != nil && drift.Deployment.State == client.DeploymentStateFailed {
+			return fmt.Errorf("Deployment of app server %s on environment %s failed", drift.AppServerName, drift.Environment)
+		}
+	}
+	return nil
+}
+
+//readDesiredState reads all desired state files
+func readDesiredState(files []string) ([]client.Snapshot, error) {
+	desiredState := []client.Snapshot{}
+	for _, fileName := range files {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't open desired state: %v", err)
+		}
+		snapshots, err := client.ReadSnapshots(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fileName, err)
+		}
+		desiredState = append(desiredState, snapshots...)
+	}
+	return desiredState, nil
+}
+
+//printDriftReport prints the drift of each app server in the given output format
+func printDriftReport(cmd *cobra.Command, drifts []client.AppServerDrift) error {
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(drifts, "", "  ")
+		if err != nil {
+			return err
+		}
+		//JSON to stdout, used in pipes
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	case "text":
+		for _, drift := range drifts {
+			PrintDrift(cmd, &drift)
+		}
+	default:
+		return fmt.Errorf("Unknown output format: %s", output)
+	}
+	return nil
+}
+
+//PrintDrift prints out the drift of an app server and the created deployment
+func PrintDrift(cmd *cobra.Command, drift *client.AppServerDrift) {
+	cmd.Println("------")
+	cmd.Printf("%s %s %s", drift.Environment, drift.AppServerName, drift.Reason)
+	if drift.Deployment != nil {
+		cmd.Printf(" deployment: %d %s", drift.Deployment.ID, drift.Deployment.State)
+	}
+	if drift.Error != "" {
+		cmd.Printf(" error: %s", drift.Error)
+	}
+	cmd.Println()
+	if drift.ActualRelease != drift.DesiredRelease && drift.DesiredRelease != "" {
+		cmd.Printf("release %s -> %s\n", util.VersionOrNone(drift.ActualRelease), drift.DesiredRelease)
+	}
+	for _, change := range drift.Changes {
+		cmd.Printf("%s %s -> %s\n", change.ApplicationName, util.VersionOrNone(change.OldVersion), util.VersionOrNone(change.NewVersion))
+	}
+}
@@ -0,0 +1,78 @@
+package reconcile
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the command "reconcile"
+func TestNewReconcileCmd(t *testing.T) {
+
+	//Write desired state file
+	desiredFile := filepath.Join(t.TempDir(), "desired.yaml")
+	desiredState := "environment: Y\nappServers:\n  - name: Test\n    applications:\n      - name: testapp\n        version: \"1.1\"\n"
+	if err := os.WriteFile(desiredFile, []byte(desiredState), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"-f", desiredFile, "--dryRun"}, "------\nY Test versions\ntestapp 1.0 -> 1.1\n"},
+		{"Test2", []string{"-f", desiredFile}, "------\nY Test versions deployment: 0 success\n"},
+		{"Test3", []string{"-f", desiredFile, "--dryRun", "--output=json"}, "[\n  {\n    \"environment\": \"Y\""},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewReconcileCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
@@ -0,0 +1,53 @@
+package relation
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	relationGetLong = `	List the consumed and provided relations of a resource release (type, related resource, related release and identifier).`
+
+	//Example command description
+	relationGetExample = `	# List the relations of an application server
+	liimactl relation get --resource=aps_bau --release=RL-19.04`
+
+	//Flags of the command
+	commandOptionsGet client.CommandOptionsGetRelation
+)
+
+//newGetCommand is a command to list the relations of a resource
+func newGetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "get [flags] ",
+		Short:   "List relations",
+		Long:    relationGetLong,
+		Example: relationGetExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runGet(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&commandOptionsGet.Resource, "resource", "", "Resource name")
+	cmd.Flags().StringVar(&commandOptionsGet.Release, "release", "", "Release of the resource, the last release if not set")
+
+	return cmd
+}
+
+//List the relations of the resource given by the flags and print them on the console
+func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	detail, err := client.GetRelations(cli, &commandOptionsGet)
+	if err != nil {
+		log.Fatalf("Couldn't get relations: %v", err)
+	}
+
+	//Print result
+	for _, relation := range detail.Relations {
+		cmd.Printf("%-10s %-40s %-12s %s\n", relation.Type, relation.RelatedResourceName, relation.RelatedResourceRelease, relation.Identifier)
+	}
+}
@@ -0,0 +1,19 @@
+package relation
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+//NewRelationCmd is a command to inspect the relations of resources
+func NewRelationCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "relation COMMAND",
+		Short: "Inspect the relations of resources",
+	}
+
+	cmd.AddCommand(newGetCommand(cli))
+
+	return cmd
+}
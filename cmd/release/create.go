@@ -0,0 +1,53 @@
+package release
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	releaseCreateLong = `	Create a release with its installation date in production, only allowed for administrators.`
+
+	//Example command description
+	releaseCreateExample = `	# Create a release
+	liimactl release create --name=RL-19.10 --installationDate=2019-10-14 --description="Autumn release"`
+
+	//Flags of the command
+	commandOptionsCreate client.CommandOptionsCreateRelease
+)
+
+//newCreateCommand is a command to create a release
+func newCreateCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "create [flags] ",
+		Short:   "Create a release",
+		Long:    releaseCreateLong,
+		Example: releaseCreateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCreate(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptionsCreate.Name, "name", "n", "", "Release name")
+	cmd.Flags().StringVarP(&commandOptionsCreate.InstallationDate, "installationDate", "d", "", "Installation date in production 'YYYY-MM-DD'")
+	cmd.Flags().StringVar(&commandOptionsCreate.Description, "description", "", "Description")
+	cmd.Flags().BoolVar(&commandOptionsCreate.MainRelease, "mainRelease", false, "Main release")
+
+	return cmd
+}
+
+//Create the release given by the flags and print it on the console
+func runCreate(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	release, err := client.CreateRelease(cli, &commandOptionsCreate)
+	if err != nil {
+		log.Fatalf("Couldn't create release: %v", err)
+	}
+
+	//Print result
+	printRelease(cmd, release)
+}
@@ -0,0 +1,48 @@
+package release
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	releaseGetLong = `	Show a release by its name or by the alias "latest" (current release in production) or "next" (next release in production).`
+
+	//Example command description
+	releaseGetExample = `	# Show a release
+	liimactl release get RL-19.04
+	# Show the next release in production
+	liimactl release get next`
+)
+
+//newGetCommand is a command to show a release
+func newGetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "get NAME [flags]",
+		Short:   "Show a release",
+		Long:    releaseGetLong,
+		Example: releaseGetExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runGet(cmd, cli, args)
+		},
+	}
+
+	return cmd
+}
+
+//Show the release given by the argument and print it on the console
+func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	release, err := client.GetRelease(cli, args[0])
+	if err != nil {
+		log.Fatalf("Couldn't get release: %v", err)
+	}
+
+	//Print result
+	printRelease(cmd, release)
+}
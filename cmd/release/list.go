@@ -0,0 +1,47 @@
+package release
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	releaseListLong = `	List all releases sorted by their installation date in production (name, id, installation date, main release and description).`
+
+	//Example command description
+	releaseListExample = `	# List all releases
+	liimactl release list`
+)
+
+//newListCommand is a command to list the releases
+func newListCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "list [flags] ",
+		Short:   "List releases",
+		Long:    releaseListLong,
+		Example: releaseListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(cmd, cli, args)
+		},
+	}
+
+	return cmd
+}
+
+//List the releases and print them on the console
+func runList(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	releases, err := client.GetReleases(cli)
+	if err != nil {
+		log.Fatalf("Couldn't get releases: %v", err)
+	}
+
+	//Print result
+	for i := range releases {
+		printRelease(cmd, &releases[i])
+	}
+}
@@ -0,0 +1,31 @@
+package release
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/client/util"
+	"github.com/spf13/cobra"
+)
+
+//NewReleaseCmd is a command to list, show and create releases
+func NewReleaseCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "release COMMAND",
+		Short: "List, show and create releases",
+	}
+
+	cmd.AddCommand(newListCommand(cli))
+	cmd.AddCommand(newGetCommand(cli))
+	cmd.AddCommand(newCreateCommand(cli))
+
+	return cmd
+}
+
+//printRelease prints a release on one line
+func printRelease(cmd *cobra.Command, release *client.Release) {
+	mainRelease := ""
+	if release.MainRelease {
+		mainRelease = "main"
+	}
+	cmd.Printf("%-20s %-6d %-10s %-4s %s\n", release.Name, release.ID, release.InstallationTime().Format(util.DayFormat), mainRelease, release.Description)
+}
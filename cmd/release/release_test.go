@@ -0,0 +1,70 @@
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the commands "release list", "release get" and "release create"
+func TestNewReleaseCmd(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"list"}, fmt.Sprintf("%-20s %-6d %-10s %-4s %s\n", "RL-18.10", 1, "2018-10-15", "", "")},
+		{"Test2", []string{"get", "RL-19.04"}, fmt.Sprintf("%-20s %-6d %-10s %-4s %s\n", "RL-19.04", 2, "2019-04-15", "main", "")},
+		{"Test3", []string{"get", "next"}, fmt.Sprintf("%-20s %-6d %-10s %-4s %s\n", "RL-99.01", 3, "2099-01-08", "", "Release of the far future")},
+		{"Test4", []string{"create", "--name=RL-19.10", "--installationDate=2019-10-14", "--description=Autumn release"}, fmt.Sprintf("%-20s %-6d %-10s %-4s %s\n", "RL-19.10", 4, "2019-10-14", "", "Autumn release")},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewReleaseCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
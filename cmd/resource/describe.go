@@ -0,0 +1,79 @@
+package resource
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	resourceDescribeLong = `	Show a resource with its releases, the related resources of a release and the hostnames of application servers.`
+
+	//Example command description
+	resourceDescribeExample = `	# Describe the last release of an application server
+	liimactl resource describe aps_bau
+	# Describe a release
+	liimactl resource describe aps_bau --release=RL-19.04`
+
+	//Flags of the command
+	commandOptionsDescribe client.CommandOptionsDescribeResource
+)
+
+//newDescribeCommand is a command to describe a resource
+func newDescribeCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "describe NAME [flags]",
+		Short:   "Describe a resource",
+		Long:    resourceDescribeLong,
+		Example: resourceDescribeExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runDescribe(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptionsDescribe.Release, "release", "r", "", "Release, the last release if not set")
+
+	return cmd
+}
+
+//Describe the resource given by the argument and print it on the console
+func runDescribe(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	commandOptionsDescribe.Name = args[0]
+	description, err := client.DescribeResource(cli, &commandOptionsDescribe)
+	if err != nil {
+		log.Fatalf("Couldn't describe resource: %v", err)
+	}
+
+	//Print result
+	resource := description.Resource
+	releases := []string{}
+	for _, release := range resource.Releases {
+		releases = append(releases, release.Release+" ("+strconv.Itoa(release.ID)+")")
+	}
+	cmd.Printf("Name:      %s\n", resource.Name)
+	cmd.Printf("Type:      %s\n", resource.Type)
+	cmd.Printf("ID:        %d\n", resource.ID)
+	cmd.Printf("Releases:  %s\n", strings.Join(releases, ", "))
+
+	if description.Release != nil {
+		cmd.Printf("Release:   %s\n", description.Release.Release)
+		cmd.Println("Relations:")
+		for _, relation := range description.Release.Relations {
+			cmd.Printf("  %-10s %-40s %s\n", relation.Type, relation.RelatedResourceName, relation.RelatedResourceRelease)
+		}
+	}
+
+	if resource.Type == client.ResourceTypeApplicationServer {
+		cmd.Println("Hostnames:")
+		for _, hostname := range description.Hostnames {
+			cmd.Printf("  %-34s %-8s %-30s %s\n", hostname.Host, hostname.Environment, hostname.Node, hostname.Domain)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package resource
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	resourceGetLong = `	List resources with their type, id and releases (release name and id).`
+
+	//Example command description
+	resourceGetExample = `	# List all application servers
+	liimactl resource get --type=APPLICATIONSERVER
+	# List the applications starting with ch_mobi_ with the release RL-19.04
+	liimactl resource get --type=APPLICATION --name="ch_mobi_*" --release=RL-19.04`
+
+	//Flags of the command
+	commandOptionsGet client.CommandOptionsGetResource
+)
+
+//newGetCommand is a command to list resources
+func newGetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "get [flags] ",
+		Short:   "List resources",
+		Long:    resourceGetLong,
+		Example: resourceGetExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runGet(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptionsGet.Type, "type", "t", "", "Resource type, example: APPLICATIONSERVER, APPLICATION, NODE")
+	cmd.Flags().StringSliceVarP(&commandOptionsGet.Name, "name", "n", []string{}, "Resource name, patterns: 'aps_*' or 're:^aps_.*'")
+	cmd.Flags().StringVarP(&commandOptionsGet.Release, "release", "r", "", "Only resources with the release")
+
+	return cmd
+}
+
+//List the resources given by the arguments and print them on the console
+func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	resources, err := client.GetResources(cli, &commandOptionsGet)
+	if err != nil {
+		log.Fatalf("Couldn't get resources: %v", err)
+	}
+
+	//Print result
+	sort.Sort(resources)
+	for _, resource := range resources {
+		releases := []string{}
+		for _, release := range resource.Releases {
+			releases = append(releases, release.Release+" ("+strconv.Itoa(release.ID)+")")
+		}
+		cmd.Printf("%-40s %-20s %-8d %s\n", resource.Name, resource.Type, resource.ID, strings.Join(releases, ", "))
+	}
+}
@@ -0,0 +1,20 @@
+package resource
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+//NewResourceCmd is a command to list and inspect resources
+func NewResourceCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "resource COMMAND",
+		Short: "List and inspect resources",
+	}
+
+	cmd.AddCommand(newGetCommand(cli))
+	cmd.AddCommand(newDescribeCommand(cli))
+
+	return cmd
+}
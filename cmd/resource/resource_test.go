@@ -0,0 +1,70 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the commands "resource get" and "resource describe"
+func TestNewResourceCmd(t *testing.T) {
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"get", "--type=APPLICATIONSERVER"}, fmt.Sprintf("%-40s %-20s %-8d %s\n", "Test", "APPLICATIONSERVER", 1, "RL-18.10 (10), RL-19.04 (11)")},
+		{"Test2", []string{"get", "--name=test*", "--release=RL-19.04"}, fmt.Sprintf("%-40s %-20s %-8d %s\n", "testapp", "APPLICATION", 2, "RL-19.04 (20)")},
+		{"Test3", []string{"describe", "Test"}, "Name:      Test\nType:      APPLICATIONSERVER\nID:        1\nReleases:  RL-18.10 (10), RL-19.04 (11)\nRelease:   RL-19.04\nRelations:\n  CONSUMED   testapp"},
+		{"Test4", []string{"describe", "node01", "--release=RL-18.10"}, "Name:      node01\nType:      NODE\nID:        3\nReleases:  RL-18.10 (30)\nRelease:   RL-18.10\nRelations:\n"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewResourceCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}
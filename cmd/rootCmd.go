@@ -9,8 +9,22 @@ import (
 	"log"
 
 	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/cmd/app"
 	"github.com/liimaorg/liimactl/cmd/deployment"
+	"github.com/liimaorg/liimactl/cmd/diff"
+	"github.com/liimaorg/liimactl/cmd/drift"
+	"github.com/liimaorg/liimactl/cmd/environment"
+	"github.com/liimaorg/liimactl/cmd/generate"
+	"github.com/liimaorg/liimactl/cmd/graph"
 	"github.com/liimaorg/liimactl/cmd/hostname"
+	"github.com/liimaorg/liimactl/cmd/matrix"
+	"github.com/liimaorg/liimactl/cmd/property"
+	"github.com/liimaorg/liimactl/cmd/reconcile"
+	"github.com/liimaorg/liimactl/cmd/relation"
+	"github.com/liimaorg/liimactl/cmd/release"
+	"github.com/liimaorg/liimactl/cmd/resource"
+	"github.com/liimaorg/liimactl/cmd/snapshot"
+	"github.com/liimaorg/liimactl/cmd/template"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -54,6 +68,20 @@ func newRootCmd() *cobra.Command {
 	flags = rootCmd.Flags()
 	rootCmd.AddCommand(deployment.NewDeploymentCmd(liimacli))
 	rootCmd.AddCommand(hostname.NewHostnameCmd(liimacli))
+	rootCmd.AddCommand(diff.NewDiffCmd(liimacli))
+	rootCmd.AddCommand(snapshot.NewSnapshotCmd(liimacli))
+	rootCmd.AddCommand(reconcile.NewReconcileCmd(liimacli))
+	rootCmd.AddCommand(drift.NewDriftCmd(liimacli))
+	rootCmd.AddCommand(resource.NewResourceCmd(liimacli))
+	rootCmd.AddCommand(release.NewReleaseCmd(liimacli))
+	rootCmd.AddCommand(environment.NewEnvironmentCmd(liimacli))
+	rootCmd.AddCommand(property.NewPropertyCmd(liimacli))
+	rootCmd.AddCommand(generate.NewGenerateCmd(liimacli))
+	rootCmd.AddCommand(template.NewTemplateCmd(liimacli))
+	rootCmd.AddCommand(relation.NewRelationCmd(liimacli))
+	rootCmd.AddCommand(graph.NewGraphCmd(liimacli))
+	rootCmd.AddCommand(app.NewAppCmd(liimacli))
+	rootCmd.AddCommand(matrix.NewMatrixCmd(liimacli))
 
 	return rootCmd
 }
@@ -84,7 +112,8 @@ func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		//Stderr, the output of commands like snapshot is redirected to files
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 
 	err = viper.Unmarshal(&config)
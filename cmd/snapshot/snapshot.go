@@ -0,0 +1,55 @@
+package snapshot
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	snapshotLong = `	Write the deployed state of an environment as YAML to stdout.
+	The snapshot contains the latest successful deployment of each application server: release, applications with versions and deployment parameters.
+	It can be deployed again with "liimactl deployment apply".`
+
+	//Example command description
+	snapshotExample = `	# Snapshot an environment into a file
+	liimactl snapshot --environment=P > p-2026-10.yaml
+	# Snapshot only some application servers
+	liimactl snapshot --environment=P --appServer=aps_bau,vvn`
+
+	//Flags of the command
+	commandOptions client.CommandOptionsSnapshot
+)
+
+//NewSnapshotCmd is a command to snapshot the deployed state of an environment
+func NewSnapshotCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "snapshot [flags] ",
+		Short:   "Snapshot the deployed versions of an environment",
+		Long:    snapshotLong,
+		Example: snapshotExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runSnapshot(cmd, cli, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&commandOptions.Environment, "environment", "e", "", "Environment")
+	cmd.Flags().StringSliceVarP(&commandOptions.AppServer, "appServer", "a", []string{}, "Application server name, all if not set")
+
+	return cmd
+}
+
+//Snapshot the environment and write it to stdout
+func runSnapshot(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	snapshot, err := client.CreateSnapshot(cli, &commandOptions)
+	if err != nil {
+		log.Fatal("Error Snapshot: ", err)
+	}
+	if err := client.WriteSnapshot(cmd.OutOrStdout(), snapshot); err != nil {
+		log.Fatal(err)
+	}
+}
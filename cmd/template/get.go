@@ -0,0 +1,52 @@
+package template
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	templateGetLong = `	Print the content of a template of a resource release or of a resource type.`
+
+	//Example command description
+	templateGetExample = `	# Print a template of an application server
+	liimactl template get server.xml --resource=aps_bau --release=RL-19.04`
+
+	//Flags of the command
+	getTarget client.TemplateTarget
+)
+
+//newGetCommand is a command to print a template
+func newGetCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "get NAME [flags]",
+		Short:   "Print a template",
+		Long:    templateGetLong,
+		Example: templateGetExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runGet(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &getTarget)
+
+	return cmd
+}
+
+//Print the content of the template given by the argument on the console
+func runGet(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	template, err := client.GetTemplate(cli, &getTarget, args[0])
+	if err != nil {
+		log.Fatalf("Couldn't get template: %v", err)
+	}
+
+	//Print result
+	fmt.Fprint(cmd.OutOrStdout(), template.FileContent)
+}
@@ -0,0 +1,55 @@
+package template
+
+import (
+	"log"
+	"strings"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	templateListLong = `	List the templates of a resource release or of a resource type with their target path and platforms.`
+
+	//Example command description
+	templateListExample = `	# List the templates of an application server
+	liimactl template list --resource=aps_bau --release=RL-19.04
+	# List the templates of a resource type
+	liimactl template list --resourceType=APPLICATIONSERVER`
+
+	//Flags of the command
+	listTarget client.TemplateTarget
+)
+
+//newListCommand is a command to list templates
+func newListCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "list [flags] ",
+		Short:   "List templates",
+		Long:    templateListLong,
+		Example: templateListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &listTarget)
+
+	return cmd
+}
+
+//List the templates given by the flags and print them on the console
+func runList(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	templates, err := client.GetTemplates(cli, &listTarget)
+	if err != nil {
+		log.Fatalf("Couldn't get templates: %v", err)
+	}
+
+	//Print result
+	for _, template := range templates {
+		cmd.Printf("%-30s %-40s %s\n", template.Name, template.TargetPath, strings.Join(template.TargetPlatforms, ", "))
+	}
+}
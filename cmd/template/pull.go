@@ -0,0 +1,56 @@
+package template
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	templatePullLong = `	Write the templates of a resource release or of a resource type to a directory, to version them in git.
+	Each template is written to a file with its name, the target paths and platforms to the file ` + client.TemplateManifest + `.`
+
+	//Example command description
+	templatePullExample = `	# Write the templates of an application server to a directory
+	liimactl template pull --resource=aps_bau --release=RL-19.04 --dir=templates/aps_bau`
+
+	//Flags of the command
+	pullTarget client.TemplateTarget
+	pullDir    string
+)
+
+//newPullCommand is a command to write templates to a directory
+func newPullCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "pull [flags] ",
+		Short:   "Write templates to a directory",
+		Long:    templatePullLong,
+		Example: templatePullExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runPull(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &pullTarget)
+	cmd.Flags().StringVarP(&pullDir, "dir", "d", "", "Template directory")
+
+	return cmd
+}
+
+//Write the templates given by the flags to the directory
+func runPull(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	if pullDir == "" {
+		log.Fatal("want template directory")
+	}
+	templates, err := client.PullTemplates(cli, &pullTarget, pullDir)
+	if err != nil {
+		log.Fatalf("Couldn't pull templates: %v", err)
+	}
+
+	//Print result
+	cmd.Printf("Pulled %d templates to %s\n", len(templates), pullDir)
+}
@@ -0,0 +1,80 @@
+package template
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/liimaorg/liimactl/cmd/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	templatePushLong = `	Update the templates of a resource release or of a resource type with a directory written by "template pull".
+	The differences against the server are shown before only the changed templates are updated.
+	Templates missing in the directory are not deleted.`
+
+	//Example command description
+	templatePushExample = `	# Show the differences of a template directory
+	liimactl template push --resource=aps_bau --release=RL-19.04 --dir=templates/aps_bau --dry-run
+	# Update the templates without confirmation
+	liimactl template push --resource=aps_bau --release=RL-19.04 --dir=templates/aps_bau --silent`
+
+	//Flags of the command
+	commandOptionsPush client.CommandOptionsPushTemplate
+	pushSilent         bool
+)
+
+//newPushCommand is a command to update templates with a directory
+func newPushCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "push [flags] ",
+		Short:   "Update templates with a directory",
+		Long:    templatePushLong,
+		Example: templatePushExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			runPush(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsPush.TemplateTarget)
+	cmd.Flags().StringVarP(&commandOptionsPush.Dir, "dir", "d", "", "Template directory")
+	cmd.Flags().BoolVar(&commandOptionsPush.DryRun, "dry-run", false, "Only show the differences, don't update the templates")
+	cmd.Flags().BoolVarP(&pushSilent, "silent", "c", false, "Silent mode, no confirmation of the update")
+
+	return cmd
+}
+
+//Show the differences of the template directory and update the changed templates after the confirmation
+func runPush(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	//Preview the changes
+	dryRun := commandOptionsPush.DryRun
+	commandOptionsPush.DryRun = true
+	changes, err := client.PushTemplates(cli, &commandOptionsPush)
+	if err != nil {
+		log.Fatalf("Couldn't push templates: %v", err)
+	}
+	for _, change := range changes {
+		fmt.Fprint(cmd.OutOrStdout(), change.Diff)
+	}
+	if len(changes) == 0 {
+		cmd.Println("No changes")
+		return
+	}
+	if dryRun {
+		cmd.Println("Dry run, no changes applied")
+		return
+	}
+
+	//Ask user for confirmation and apply the previewed changes, the directory isn't read and compared again
+	if pushSilent || cmdutil.AskYesNo(fmt.Sprintf("Do you really want to update %d templates", len(changes))) {
+		changes, err = client.ApplyTemplateChanges(cli, &commandOptionsPush.TemplateTarget, changes)
+		if err != nil {
+			log.Fatalf("Couldn't push templates: %v", err)
+		}
+		cmd.Printf("Pushed %d templates\n", len(changes))
+	}
+}
@@ -0,0 +1,58 @@
+package template
+
+import (
+	"log"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//Long command description
+	templatePutLong = `	Create or update a template of a resource release or of a resource type with the content of a file.
+	The target path and platforms of an existing template are only changed if set.`
+
+	//Example command description
+	templatePutExample = `	# Update a template of an application server
+	liimactl template put server.xml --resource=aps_bau --release=RL-19.04 -f server.xml
+	# Create a template of a resource type
+	liimactl template put log4j.xml --resourceType=APPLICATIONSERVER -f log4j.xml --targetPath=conf/log4j.xml`
+
+	//Flags of the command
+	commandOptionsPut client.CommandOptionsPutTemplate
+)
+
+//newPutCommand is a command to create or update a template
+func newPutCommand(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:     "put NAME [flags]",
+		Short:   "Create or update a template",
+		Long:    templatePutLong,
+		Example: templatePutExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runPut(cmd, cli, args)
+		},
+	}
+
+	addTargetFlags(cmd.Flags(), &commandOptionsPut.TemplateTarget)
+	cmd.Flags().StringVarP(&commandOptionsPut.File, "file", "f", "", "File with the content of the template")
+	cmd.Flags().StringVar(&commandOptionsPut.TargetPath, "targetPath", "", "Target path of the generated file, the name for new templates if not set")
+	cmd.Flags().StringSliceVar(&commandOptionsPut.TargetPlatforms, "targetPlatform", []string{}, "Target platforms")
+
+	return cmd
+}
+
+//Create or update the template given by the argument and print it on the console
+func runPut(cmd *cobra.Command, cli *client.Cli, args []string) {
+
+	commandOptionsPut.Name = args[0]
+	template, err := client.PutTemplate(cli, &commandOptionsPut)
+	if err != nil {
+		log.Fatalf("Couldn't put template: %v", err)
+	}
+
+	//Print result
+	cmd.Printf("Put template %s (%d) with target path %s\n", template.Name, template.ID, template.TargetPath)
+}
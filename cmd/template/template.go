@@ -0,0 +1,31 @@
+package template
+
+import (
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+//NewTemplateCmd is a command to manage the templates of resources and resource types
+func NewTemplateCmd(cli *client.Cli) *cobra.Command {
+
+	var cmd = &cobra.Command{
+		Use:   "template COMMAND",
+		Short: "Manage templates of resources and resource types",
+	}
+
+	cmd.AddCommand(newListCommand(cli))
+	cmd.AddCommand(newGetCommand(cli))
+	cmd.AddCommand(newPutCommand(cli))
+	cmd.AddCommand(newPullCommand(cli))
+	cmd.AddCommand(newPushCommand(cli))
+
+	return cmd
+}
+
+//addTargetFlags adds the flags of the resource, release and resource type of the templates
+func addTargetFlags(flags *pflag.FlagSet, target *client.TemplateTarget) {
+	flags.StringVar(&target.Resource, "resource", "", "Resource name")
+	flags.StringVar(&target.Release, "release", "", "Release of the resource, the last release if not set")
+	flags.StringVar(&target.ResourceType, "resourceType", "", "Resource type, example: APPLICATIONSERVER (instead of a resource)")
+}
@@ -0,0 +1,83 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liimaorg/liimactl/client"
+	"github.com/spf13/pflag"
+)
+
+//Tests the commands "template list", "get", "put", "pull" and "push"
+func TestNewTemplateCmd(t *testing.T) {
+
+	//Write template files
+	dir := t.TempDir()
+	pushDir := t.TempDir()
+	os.WriteFile(filepath.Join(pushDir, "templates.yaml"), []byte("- name: app.properties\n  targetPath: conf/app.properties\n- name: setenv.sh\n  targetPath: bin/setenv.sh\n  targetPlatforms: [EAP 7]\n"), 0644)
+	os.WriteFile(filepath.Join(pushDir, "app.properties"), []byte("app.name=${name}\napp.version=${version}\n"), 0644)
+	os.WriteFile(filepath.Join(pushDir, "setenv.sh"), []byte("#!/bin/sh\nJAVA_OPTS=-Xmx${heap}\n"), 0644)
+
+	//Tests
+	tests := []struct {
+		name string   //Name of the test
+		args []string //Arguments
+		want string   //Wanted testresult
+	}{
+		{"Test1", []string{"list", "--resource=Test"}, fmt.Sprintf("%-30s %-40s %s\n%-30s %-40s %s\n", "app.properties", "conf/app.properties", "", "setenv.sh", "bin/setenv.sh", "EAP 7")},
+		{"Test2", []string{"list", "--resourceType=applicationserver"}, fmt.Sprintf("%-30s %-40s %s\n", "log4j.xml", "conf/log4j.xml", "")},
+		{"Test3", []string{"get", "setenv.sh", "--resource=Test", "--release=RL-18.10"}, "#!/bin/sh\nJAVA_OPTS=-Xmx${heap}\n"},
+		{"Test4", []string{"put", "server.xml", "--resourceType=APPLICATIONSERVER", "-f", filepath.Join(pushDir, "setenv.sh")}, "Put template server.xml (100) with target path server.xml\n"},
+		{"Test5", []string{"pull", "--resource=Test", "--dir=" + dir}, "Pulled 2 templates to " + dir + "\n"},
+		{"Test6", []string{"push", "--resource=Test", "--dir=" + pushDir, "--dry-run"}, "--- app.properties (server)\n+++ app.properties (local)\n@@ -2,3 +2,4 @@\n targetPlatforms: \n \n app.name=${name}\n+app.version=${version}\nDry run, no changes applied\n"},
+		{"Test7", []string{"push", "--resource=Test", "--dir=" + pushDir, "--silent"}, "--- app.properties (server)"},
+		{"Test8", []string{"push", "--resource=Test", "--dir=" + dir}, "No changes\n"},
+	}
+
+	//Init config
+	var flags *pflag.FlagSet
+	liimacli := &client.Cli{}
+	config, err := initConfig(flags)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	//Create mock client
+	liimacli.Client, err = client.NewMockClient(config)
+
+	//Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			//Create command
+			cmd := NewTemplateCmd(liimacli)
+
+			//Set commands output to buffer
+			buf := new(bytes.Buffer)
+			cmd.SetOutput(buf)
+
+			//Set test arguments
+			cmd.SetArgs(tt.args)
+
+			//Execute command
+			err = cmd.Execute()
+			if err != nil {
+				t.Errorf("Execute() failed with %v", err)
+			}
+			//Check result
+			if got := buf.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Commands-Output = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig(flags *pflag.FlagSet) (*client.Config, error) {
+	var config client.Config
+	return &config, nil
+}